@@ -0,0 +1,147 @@
+package gogeospace
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jdejesus007/gogeospace/disc"
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+const (
+	// ANTIMERIDIAN_TOLERANCE degrees a result may overshoot ±180 from rounding
+	// before it is split instead of wrapped
+	ANTIMERIDIAN_TOLERANCE = 1e-9
+)
+
+// splitAntimeridian splits the parts of a result lying past the antimeridian
+// at ±180 and shifts them back so every lng lies in [-180, 180] - a polygon
+// crossing it comes back as one polygon each side
+func splitAntimeridian(collection *point.GeometryCollection, areaOnly bool) (*point.GeometryCollection, error) {
+	minLng, maxLng := math.Inf(1), math.Inf(-1)
+	eachPoint(collection, func(p *point.Point) {
+		minLng = math.Min(minLng, p.Lng)
+		maxLng = math.Max(maxLng, p.Lng)
+	})
+
+	// Rings around a pole are closed along it when rebuilt
+	aroundPole := false
+	for _, polygon := range collection.Polygons {
+		for _, ring := range append([][]*point.Point{polygon.Exterior}, polygon.Interiors...) {
+			if _, _, ok := poleRing(ring); ok {
+				aroundPole = true
+			}
+		}
+	}
+
+	if !aroundPole && minLng >= -180.0-ANTIMERIDIAN_TOLERANCE && maxLng <= 180.0+ANTIMERIDIAN_TOLERANCE {
+		eachPoint(collection, func(p *point.Point) {
+			p.Lng = math.Max(-180.0, math.Min(180.0, p.Lng))
+		})
+		return collection, nil
+	}
+
+	// Rebuild each part in a degree plane that keeps the lngs as they are -
+	// GEOS cannot intersect whole geometry collections
+	f := frame{lng: (minLng + maxLng) / 2.0}
+	var parts []point.Geometry
+	for _, polygon := range collection.Polygons {
+		parts = append(parts, polygon)
+	}
+	for _, line := range collection.LineStrings {
+		parts = append(parts, line)
+	}
+	for _, p := range collection.Points {
+		parts = append(parts, p)
+	}
+
+	split := &point.GeometryCollection{}
+	for _, shift := range []float64{-360.0, 0, 360.0} {
+		// Box of the lngs that land in [-180, 180] once shifted
+		box, err := lngBox(-180.0-shift, 180.0-shift)
+		if err != nil {
+			return nil, err
+		}
+
+		shifted := &point.GeometryCollection{}
+		for _, part := range parts {
+			geo, err := getGeosGeometry(part, f, Options{})
+			if err != nil {
+				return nil, err
+			}
+
+			clipped, err := geo.Intersection(box)
+			if err != nil {
+				return nil, fmt.Errorf("failed splitting at the antimeridian: %w", err)
+			}
+
+			// Clipping a polygon leaves lines along the seam where it touches
+			_, areal := part.(*point.Polygon)
+			if err := appendGeos(shifted, clipped, f, areaOnly || areal); err != nil {
+				return nil, err
+			}
+		}
+
+		eachPoint(shifted, func(p *point.Point) {
+			p.Lng = math.Max(-180.0, math.Min(180.0, p.Lng+shift))
+		})
+
+		split.Polygons = append(split.Polygons, shifted.Polygons...)
+		split.LineStrings = append(split.LineStrings, shifted.LineStrings...)
+		split.Points = append(split.Points, shifted.Points...)
+	}
+
+	return split, nil
+}
+
+// lngBox creates the box of every lat between the west and east lngs in a
+// degree plane - closures past the poles included
+func lngBox(west, east float64) (*geom.Geometry, error) {
+	south, north := -90.0-POLE_OVERSHOOT, 90.0+POLE_OVERSHOOT
+	box, err := geom.NewPolygon([]geom.Coord{
+		geom.NewCoord(south, west),
+		geom.NewCoord(south, east),
+		geom.NewCoord(north, east),
+		geom.NewCoord(north, west),
+		geom.NewCoord(south, west),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed creating lng box: %w", err)
+	}
+	return box, nil
+}
+
+// unwrapRing copies a ring without nil points with its lngs unwrapped edge by
+// edge around the center of the ring - rings around a pole are closed along it
+func unwrapRing(ring []*point.Point) []*point.Point {
+	_, lng := centerOf(ring)
+	if raw, pole, ok := poleRing(ring); ok {
+		return disc.EnclosePole(raw, pole, lng)
+	}
+
+	unwrapped := make([]*point.Point, len(ring))
+	for i, unwrappedLng := range unwrapLngs(ring, lng) {
+		unwrapped[i] = &point.Point{Lat: ring[i].Lat, Lng: unwrappedLng}
+	}
+	return unwrapped
+}
+
+// eachPoint calls fn with every point of the collection
+func eachPoint(collection *point.GeometryCollection, fn func(p *point.Point)) {
+	for _, polygon := range collection.Polygons {
+		for _, ring := range append([][]*point.Point{polygon.Exterior}, polygon.Interiors...) {
+			for _, p := range ring {
+				fn(p)
+			}
+		}
+	}
+	for _, line := range collection.LineStrings {
+		for _, p := range line {
+			fn(p)
+		}
+	}
+	for _, p := range collection.Points {
+		fn(p)
+	}
+}
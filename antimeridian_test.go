@@ -0,0 +1,174 @@
+package gogeospace
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+// box returns the open ring of the box between two lats from the west to the
+// east lng
+func box(south, west, north, east float64) point.Ring {
+	return point.Ring{
+		{Lat: south, Lng: west},
+		{Lat: south, Lng: east},
+		{Lat: north, Lng: east},
+		{Lat: north, Lng: west},
+	}
+}
+
+func TestIntersectPolygonWithDiscAcrossAntimeridian(t *testing.T) {
+	tests := []struct {
+		name     string
+		polygon  point.Ring
+		center   *point.Point
+		polygons int
+	}{
+		{name: "disc far from box", polygon: box(-10, 170, 10, -170), center: &point.Point{}, polygons: 0},
+		{name: "disc inside box", polygon: box(-10, 170, 10, -170), center: &point.Point{Lng: 175}, polygons: 1},
+		{name: "disc on antimeridian", polygon: box(-10, 170, 10, -170), center: &point.Point{Lng: 180}, polygons: 2},
+		{name: "box past window edge", polygon: box(-10, 100, 10, -110), center: &point.Point{Lng: -50}, polygons: 0},
+		{name: "disc in box past window edge", polygon: box(-10, 100, 10, -110), center: &point.Point{Lng: -120}, polygons: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collection, err := IntersectPolygonWithDisc(test.polygon, test.center, 100000, Options{})
+			if err != nil {
+				t.Fatalf("IntersectPolygonWithDisc() error = %v", err)
+			}
+			if len(collection.Polygons) != test.polygons {
+				t.Errorf("IntersectPolygonWithDisc() = %d polygons, want %d", len(collection.Polygons), test.polygons)
+			}
+		})
+	}
+}
+
+func TestPreparedIntersectDiscAcrossAntimeridian(t *testing.T) {
+	prepared, err := NewPreparedPolygon(box(-10, 170, 10, -170))
+	if err != nil {
+		t.Fatalf("NewPreparedPolygon() error = %v", err)
+	}
+	defer prepared.Close()
+
+	collection, err := prepared.IntersectDisc(&point.Point{}, 100000)
+	if err != nil {
+		t.Fatalf("IntersectDisc() error = %v", err)
+	}
+	if len(collection.Polygons) != 0 {
+		t.Errorf("IntersectDisc() = %d polygons, want 0", len(collection.Polygons))
+	}
+
+	collection, err = prepared.IntersectDisc(&point.Point{Lng: -175}, 100000)
+	if err != nil {
+		t.Fatalf("IntersectDisc() error = %v", err)
+	}
+	if len(collection.Polygons) != 1 {
+		t.Errorf("IntersectDisc() = %d polygons, want 1", len(collection.Polygons))
+	}
+}
+
+func TestFullWidthRings(t *testing.T) {
+	band := box(-10, -180, 10, 180)
+	capBox := box(80, -180, 90, 180)
+	polarDisc := point.Ring(haversine.CreateDisc(89, 45, 500000))
+	discArea, err := Area(polarDisc, MethodHaversine)
+	if err != nil {
+		t.Fatalf("Area() error = %v", err)
+	}
+
+	for _, lng := range []float64{0, 30, -100} {
+		collection, err := IntersectPolygonWithDisc(band, &point.Point{Lng: lng}, 100000, Options{Generator: haversine.Generator{}})
+		if err != nil {
+			t.Fatalf("IntersectPolygonWithDisc() at lng %v error = %v", lng, err)
+		}
+		if len(collection.Polygons) != 1 {
+			t.Errorf("IntersectPolygonWithDisc() at lng %v = %d polygons, want 1", lng, len(collection.Polygons))
+		}
+	}
+
+	contains, err := Contains(band, &point.Point{Lng: 50})
+	if err != nil || !contains {
+		t.Errorf("Contains(band) = %v, %v, want true", contains, err)
+	}
+
+	collection, err := IntersectPolygonWithDisc(capBox, &point.Point{Lat: 89, Lng: 45}, 500000, Options{Generator: haversine.Generator{}, AreaOnly: true})
+	if err != nil {
+		t.Fatalf("IntersectPolygonWithDisc(cap) error = %v", err)
+	}
+	area, err := Area(collection.Polygons, MethodHaversine)
+	if err != nil || math.Abs(area-discArea) > 1e-3*discArea {
+		t.Errorf("IntersectPolygonWithDisc(cap) area = %v, %v, want %v", area, err, discArea)
+	}
+
+	difference, err := Difference(polarDisc, capBox)
+	if err != nil || len(difference) != 0 {
+		t.Errorf("Difference(disc, cap) = %d polygons, %v, want 0", len(difference), err)
+	}
+
+	radius := haversine.EARTH_RADIUS_CONSTANT
+	tests := []struct {
+		name   string
+		ring   point.Ring
+		area   float64
+		bounds Bounds
+	}{
+		{name: "band", ring: band, area: 2 * math.Pi * radius * radius * 2 * math.Sin(10*math.Pi/180), bounds: Bounds{MinLat: -10, MinLng: -180, MaxLat: 10, MaxLng: 180}},
+		{name: "cap", ring: capBox, area: 2 * math.Pi * radius * radius * (1 - math.Sin(80*math.Pi/180)), bounds: Bounds{MinLat: 80, MinLng: -180, MaxLat: 90, MaxLng: 180}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			area, err := Area(test.ring, MethodHaversine)
+			if err != nil || math.Abs(area-test.area) > 1e-3*test.area {
+				t.Errorf("Area() = %v, %v, want %v", area, err, test.area)
+			}
+
+			bounds, err := BoundingBox(test.ring)
+			if err != nil {
+				t.Fatalf("BoundingBox() error = %v", err)
+			}
+			if math.Abs(bounds.MinLat-test.bounds.MinLat) > 1e-9 || math.Abs(bounds.MaxLat-test.bounds.MaxLat) > 1e-9 ||
+				bounds.MinLng != test.bounds.MinLng || bounds.MaxLng != test.bounds.MaxLng {
+				t.Errorf("BoundingBox() = %+v, want %+v", *bounds, test.bounds)
+			}
+		})
+	}
+
+	centroid, err := Centroid(capBox)
+	if err != nil || centroid.Lat < 89.9 {
+		t.Errorf("Centroid(cap) = %+v, %v, want the north pole", centroid, err)
+	}
+
+	if _, err := Contains(band, &point.Point{Lng: 50}, Options{Projection: ProjectionAzimuthalEquidistant}); !errors.Is(err, ErrInvalidPolygon) {
+		t.Errorf("Contains(band) in a projection error = %v, want %v", err, ErrInvalidPolygon)
+	}
+}
+
+func TestIntersectPolygonWithDiscAtSeam(t *testing.T) {
+	tests := []struct {
+		name    string
+		polygon point.Ring
+		center  *point.Point
+		radius  float64
+	}{
+		{name: "box ending at antimeridian", polygon: box(50, 170, 52, 180), center: &point.Point{Lat: 51, Lng: -179.9}, radius: 100000},
+		{name: "cap around pole", polygon: box(80, -180, 90, 180), center: &point.Point{Lat: 85}, radius: 800000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collection, err := IntersectPolygonWithDisc(test.polygon, test.center, test.radius, Options{})
+			if err != nil {
+				t.Fatalf("IntersectPolygonWithDisc() error = %v", err)
+			}
+			if len(collection.Polygons) != 1 || len(collection.LineStrings) != 0 || len(collection.Points) != 0 {
+				t.Errorf("IntersectPolygonWithDisc() = %d polygons, %d lines, %d points, want 1 polygon",
+					len(collection.Polygons), len(collection.LineStrings), len(collection.Points))
+			}
+		})
+	}
+}
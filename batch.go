@@ -0,0 +1,195 @@
+package gogeospace
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+const (
+	// BATCH_WINDOW_PER_WORKER jobs per worker that may be in flight or waiting
+	// to be emitted in order - bounds the memory of slow jobs holding up the
+	// results behind them
+	BATCH_WINDOW_PER_WORKER = 4
+)
+
+// DiscJob is one polygon and disc intersection of a batch
+type DiscJob struct {
+	// Polygon ring, polygon with holes or multi polygon of lat,lng in degrees
+	Polygon point.Polygonal
+	// Center point lat,lng in degrees
+	Center *point.Point
+	// Radius off center point to create the disc in meters
+	Radius float64
+	// Generator creates the disc such as haversine.Generator or
+	// vincenty.Generator - defaults to the batch options generator
+	Generator DiscGenerator
+}
+
+// DiscResult is the outcome of one job of a batch
+type DiscResult struct {
+	// Index of the job in the slice or stream
+	Index int
+	// Collection the intersection like IntersectPolygonWithDisc returns it
+	Collection *point.GeometryCollection
+	// Err why the job failed - other jobs carry on
+	Err error
+}
+
+// BatchOptions are settings for batch intersections
+type BatchOptions struct {
+	// Options used by every job
+	Options Options
+	// Workers running jobs at the same time - defaults to GOMAXPROCS
+	Workers int
+	// Progress is called after each result in order with the jobs done and
+	// the total - zero total for streams. Called from one goroutine at a time
+	Progress func(done, total int)
+}
+
+// IntersectDiscBatch runs IntersectPolygonWithDisc for every job on a pool of
+// workers and returns the results in job order - a failing job only fails its
+// own result. Jobs not started once the context is done fail with ctx.Err()
+// and a job whose running step overruns its timeout holds up the results
+// behind it.
+// GEOS calls are serialized by the binding behind one handle and every GEOS
+// geometry stays with the worker that built it until its finalizer frees it,
+// so only pure Go results leave the workers
+// Params:
+// Ctx cancels the jobs not finished yet
+// Jobs polygon, center, radius and disc generator of each intersection
+// Batch the worker count, options and progress callback
+func IntersectDiscBatch(ctx context.Context, jobs []DiscJob, batch BatchOptions) []DiscResult {
+	in := make(chan DiscJob)
+	go func() {
+		defer close(in)
+		for _, job := range jobs {
+			select {
+			case in <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := make([]DiscResult, len(jobs))
+	emitted := 0
+	for result := range intersectDiscStream(ctx, in, batch, len(jobs)) {
+		results[result.Index] = result
+		emitted++
+	}
+
+	// Results are emitted in order so only the tail was never started
+	for i := emitted; i < len(results); i++ {
+		results[i] = DiscResult{Index: i, Err: ctx.Err()}
+	}
+
+	return results
+}
+
+// IntersectDiscStream runs IntersectPolygonWithDisc for every job received on
+// a pool of workers and sends the results in job order - the results channel
+// closes once the jobs channel is closed and every result is sent. Stops
+// reading jobs once the context is done. The results must be drained
+// Params:
+// Ctx cancels the jobs not finished yet
+// Jobs polygon, center, radius and disc generator of each intersection
+// Batch the worker count, options and progress callback
+func IntersectDiscStream(ctx context.Context, jobs <-chan DiscJob, batch BatchOptions) <-chan DiscResult {
+	return intersectDiscStream(ctx, jobs, batch, 0)
+}
+
+// indexedDiscJob is a job with its position in the stream
+type indexedDiscJob struct {
+	index int
+	job   DiscJob
+}
+
+func intersectDiscStream(ctx context.Context, jobs <-chan DiscJob, batch BatchOptions, total int) <-chan DiscResult {
+	workers := batch.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	work := make(chan indexedDiscJob)
+	done := make(chan DiscResult, workers)
+	results := make(chan DiscResult, workers)
+
+	// A slot is taken per job read and given back once its result is sent
+	window := make(chan struct{}, workers*BATCH_WINDOW_PER_WORKER)
+
+	go func() {
+		defer close(work)
+		for index := 0; ; index++ {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case job, ok := <-jobs:
+				if !ok {
+					return
+				}
+				work <- indexedDiscJob{index: index, job: job}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for w := range work {
+				collection, err := runDiscJob(ctx, w.job, batch.Options)
+				done <- DiscResult{Index: w.index, Collection: collection, Err: err}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	// Reorder the results as they finish into job order
+	go func() {
+		defer close(results)
+		pending := make(map[int]DiscResult)
+		next := 0
+		for result := range done {
+			pending[result.Index] = result
+			for {
+				result, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				results <- result
+				<-window
+				next++
+
+				if batch.Progress != nil {
+					batch.Progress(next, total)
+				}
+			}
+		}
+	}()
+
+	return results
+}
+
+// runDiscJob intersects one job with the batch options and its generator
+func runDiscJob(ctx context.Context, job DiscJob, opts Options) (*point.GeometryCollection, error) {
+	if job.Generator != nil {
+		opts.Generator = job.Generator
+	}
+
+	return IntersectPolygonWithDiscContext(ctx, job.Polygon, job.Center, job.Radius, opts)
+}
@@ -0,0 +1,199 @@
+package gogeospace
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+// slowGenerator creates haversine discs after a delay so jobs finish out of
+// order
+type slowGenerator struct {
+	delay time.Duration
+}
+
+func (g slowGenerator) CreateDisc(lat, lng, radius float64) []*point.Point {
+	time.Sleep(g.delay)
+	return haversine.Generator{}.CreateDisc(lat, lng, radius)
+}
+
+// discJobs returns jobs of growing radii around the center of the box - the
+// earlier jobs are the slower ones
+func discJobs(n int) []DiscJob {
+	jobs := make([]DiscJob, n)
+	for i := range jobs {
+		jobs[i] = DiscJob{
+			Polygon:   box(0, 0, 1, 1),
+			Center:    &point.Point{Lat: 0.5, Lng: 0.5},
+			Radius:    float64(10000 * (i + 1)),
+			Generator: slowGenerator{delay: time.Duration(n-i) * 5 * time.Millisecond},
+		}
+	}
+	return jobs
+}
+
+func TestIntersectDiscBatchKeepsJobOrder(t *testing.T) {
+	jobs := discJobs(8)
+
+	var mu sync.Mutex
+	var progress [][2]int
+	results := IntersectDiscBatch(context.Background(), jobs, BatchOptions{
+		Workers: 4,
+		Progress: func(done, total int) {
+			mu.Lock()
+			defer mu.Unlock()
+			progress = append(progress, [2]int{done, total})
+		},
+	})
+
+	if len(results) != len(jobs) {
+		t.Fatalf("IntersectDiscBatch() results = %d, want %d", len(results), len(jobs))
+	}
+	for i, result := range results {
+		if result.Index != i || result.Err != nil {
+			t.Fatalf("IntersectDiscBatch() result %d = index %d, error %v", i, result.Index, result.Err)
+		}
+		want, err := IntersectPolygonWithDisc(jobs[i].Polygon, jobs[i].Center, jobs[i].Radius, Options{Generator: haversine.Generator{}})
+		if err != nil {
+			t.Fatalf("IntersectPolygonWithDisc() error = %v", err)
+		}
+		if !reflect.DeepEqual(result.Collection, want) {
+			t.Errorf("IntersectDiscBatch() result %d is not the intersection of job %d", i, i)
+		}
+	}
+
+	// Progress counts every result once in order
+	mu.Lock()
+	defer mu.Unlock()
+	if len(progress) != len(jobs) {
+		t.Fatalf("Progress calls = %d, want %d", len(progress), len(jobs))
+	}
+	for i, p := range progress {
+		if p != [2]int{i + 1, len(jobs)} {
+			t.Errorf("Progress call %d = %v, want %v", i, p, [2]int{i + 1, len(jobs)})
+		}
+	}
+}
+
+func TestIntersectDiscBatchErrorsOnlyFailTheirJob(t *testing.T) {
+	jobs := discJobs(5)
+	jobs[1].Radius = math.NaN()
+	jobs[3].Polygon = point.Ring{}
+
+	results := IntersectDiscBatch(context.Background(), jobs, BatchOptions{Workers: 2})
+	for i, result := range results {
+		switch i {
+		case 1:
+			if !errors.Is(result.Err, ErrInvalidDisc) {
+				t.Errorf("result %d error = %v, want %v", i, result.Err, ErrInvalidDisc)
+			}
+		case 3:
+			if result.Err == nil {
+				t.Errorf("result %d error = nil, want the empty polygon error", i)
+			}
+		default:
+			if result.Err != nil || result.Collection == nil {
+				t.Errorf("result %d = %v, %v, want an intersection", i, result.Collection, result.Err)
+			}
+		}
+	}
+}
+
+func TestIntersectDiscBatchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	jobs := discJobs(20)
+	results := IntersectDiscBatch(ctx, jobs, BatchOptions{
+		Workers: 2,
+		Progress: func(done, total int) {
+			if done == 2 {
+				cancel()
+			}
+		},
+	})
+
+	if len(results) != len(jobs) {
+		t.Fatalf("IntersectDiscBatch() results = %d, want %d", len(results), len(jobs))
+	}
+	if results[0].Err != nil || results[1].Err != nil {
+		t.Errorf("IntersectDiscBatch() first errors = %v, %v, want nil", results[0].Err, results[1].Err)
+	}
+	if last := results[len(results)-1]; last.Index != len(jobs)-1 || !errors.Is(last.Err, context.Canceled) {
+		t.Errorf("IntersectDiscBatch() last result = index %d, error %v, want %v", last.Index, last.Err, context.Canceled)
+	}
+}
+
+func TestIntersectDiscStreamCancelledMidStream(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// An endless stream of jobs until the context is done
+	jobs := make(chan DiscJob)
+	go func() {
+		defer close(jobs)
+		job := discJobs(1)[0]
+		for {
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var totals []int
+	results := IntersectDiscStream(ctx, jobs, BatchOptions{
+		Workers:  3,
+		Progress: func(done, total int) { totals = append(totals, total) },
+	})
+
+	received := 0
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		for result := range results {
+			if result.Index != received {
+				t.Errorf("IntersectDiscStream() result %d has index %d", received, result.Index)
+			}
+			received++
+			if received == 5 {
+				cancel()
+			}
+		}
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(10 * time.Second):
+		t.Fatal("IntersectDiscStream() results channel not closed after cancel")
+	}
+
+	if received < 5 {
+		t.Errorf("IntersectDiscStream() results = %d, want at least 5", received)
+	}
+	for _, total := range totals {
+		if total != 0 {
+			t.Errorf("Progress total = %d, want 0 for streams", total)
+		}
+	}
+
+	// Every worker, reader and reorder goroutine exits
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("goroutines = %d after the stream, want at most %d", after, before)
+	}
+}
@@ -0,0 +1,80 @@
+package gogeospace
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+const (
+	// DEFAULT_QUAD_SEGS segments approximating a quarter circle of a buffer
+	DEFAULT_QUAD_SEGS = 8
+	// DEFAULT_MITRE_LIMIT ratio of the buffer distance a mitred join may reach
+	DEFAULT_MITRE_LIMIT = 5.0
+)
+
+// BufferMeters returns the area within a distance in meters of a geometry -
+// points and collections of points grow into discs, line strings into
+// corridors and polygons grow or, with a negative distance, shrink. Distances
+// are measured in one spherical azimuthal equidistant projection centered on
+// the geometry - a spherical approximation ignoring the Method ellipsoid that
+// is true from the center only. Distances across the projection are off by
+// about 0.1% at 500 km from the center and 2% at 2000 km
+// Params:
+// Geometry points, line strings, rings, polygons, multi polygons or
+// collections of lat,lng in degrees
+// Meters distance to grow by - negative shrinks polygons
+// Opts optional settings such as the buffer cap and join styles - the
+// projection is always azimuthal equidistant
+func BufferMeters(geometry point.Geometry, meters float64, opts ...Options) (multiPolygon point.MultiPolygon, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	if math.IsNaN(meters) || math.IsInf(meters, 0) {
+		return nil, fmt.Errorf("%w: buffer of %f meters", ErrInvalidDistance, meters)
+	}
+
+	options := getOptions(opts)
+	options.Projection = ProjectionAzimuthalEquidistant
+
+	f, err := newFrameForGeometry(options, geometry)
+	if err != nil {
+		return nil, err
+	}
+
+	geo, err := getGeosGeometry(geometry, f, options)
+	if err != nil {
+		return nil, err
+	}
+
+	buffered, err := geo.BufferWithOpts(meters, bufferOpts(options.BufferStyle))
+	if err != nil {
+		return nil, fmt.Errorf("failed buffering geometry: %w", err)
+	}
+
+	collection, err := collectionFromGeos(buffered, f, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.Polygons, nil
+}
+
+// bufferOpts fills the unset buffer style fields with their defaults
+func bufferOpts(style geom.BufferOpts) geom.BufferOpts {
+	if style.QuadSegs <= 0 {
+		style.QuadSegs = DEFAULT_QUAD_SEGS
+	}
+	if style.CapStyle == 0 {
+		style.CapStyle = geom.CapRound
+	}
+	if style.JoinStyle == 0 {
+		style.JoinStyle = geom.JoinRound
+	}
+	if style.MitreLimit <= 0 {
+		style.MitreLimit = DEFAULT_MITRE_LIMIT
+	}
+	return style
+}
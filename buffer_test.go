@@ -0,0 +1,93 @@
+package gogeospace
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+func TestBufferMeters(t *testing.T) {
+	// Round buffers are polygons of DEFAULT_QUAD_SEGS segments per quarter
+	polygonArea := 2 * DEFAULT_QUAD_SEGS * math.Sin(math.Pi/(2*DEFAULT_QUAD_SEGS))
+	lineLength := 0.1 * math.Pi / 180 * 6371008.8
+
+	// A square grows by a strip along its perimeter plus corners of the width
+	square := box(-0.05, -0.05, 0.05, 0.05)
+	squareArea, err := Area(square, MethodHaversine)
+	if err != nil {
+		t.Fatalf("Area() error = %v", err)
+	}
+	squarePerimeter, err := Perimeter(square, MethodHaversine)
+	if err != nil {
+		t.Fatalf("Perimeter() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		geometry point.Geometry
+		meters   float64
+		opts     Options
+		area     float64
+		// within is the allowed difference in square meters - 1% of the area
+		// when zero
+		within float64
+	}{
+		{name: "point", geometry: &point.Point{Lat: 45, Lng: 10}, meters: 1000, area: polygonArea * 1000 * 1000},
+		{name: "line", geometry: point.LineString{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 0.1}}, meters: 100,
+			area: 2*100*lineLength + polygonArea*100*100},
+		{name: "line with flat caps", geometry: point.LineString{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 0.1}}, meters: 100,
+			opts: Options{BufferStyle: geom.BufferOpts{CapStyle: geom.CapFlat}}, area: 2 * 100 * lineLength},
+		{name: "line with square caps", geometry: point.LineString{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 0.1}}, meters: 100,
+			opts: Options{BufferStyle: geom.BufferOpts{CapStyle: geom.CapSquare}}, area: 2 * 100 * (lineLength + 200)},
+		{name: "grown square", geometry: square, meters: 100,
+			area: squareArea + squarePerimeter*100 + polygonArea*100*100, within: 1000},
+		{name: "grown square with mitre joins", geometry: square, meters: 100,
+			opts: Options{BufferStyle: geom.BufferOpts{JoinStyle: geom.JoinMitre}}, area: squareArea + squarePerimeter*100 + 4*100*100, within: 1000},
+		{name: "shrunk square", geometry: square, meters: -100,
+			area: squareArea - squarePerimeter*100 + 4*100*100, within: 1000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buffered, err := BufferMeters(test.geometry, test.meters, test.opts)
+			if err != nil {
+				t.Fatalf("BufferMeters() error = %v", err)
+			}
+			within := test.within
+			if within == 0 {
+				within = 1e-2 * test.area
+			}
+			area, err := Area(buffered, MethodHaversine)
+			if err != nil || math.Abs(area-test.area) > within {
+				t.Errorf("BufferMeters() area = %v, %v, want %v", area, err, test.area)
+			}
+		})
+	}
+}
+
+func TestBufferMetersInvalidCoordinates(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry point.Geometry
+	}{
+		{name: "NaN point", geometry: &point.Point{Lat: math.NaN(), Lng: 0}},
+		{name: "infinite point", geometry: &point.Point{Lat: 0, Lng: math.Inf(-1)}},
+		{name: "line past pole", geometry: point.LineString{{Lat: 0, Lng: 0}, {Lat: 91, Lng: 0}}},
+		{name: "collection with NaN point", geometry: &point.GeometryCollection{Points: []*point.Point{{Lat: 1, Lng: 1}, {Lat: math.NaN(), Lng: 1}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := BufferMeters(test.geometry, 10); !errors.Is(err, ErrInvalidPolygon) {
+				t.Errorf("BufferMeters() error = %v, want %v", err, ErrInvalidPolygon)
+			}
+		})
+	}
+
+	if _, err := Corridor([]*point.Point{{Lat: 0, Lng: 0}, {Lat: math.NaN(), Lng: 1}}, 10); !errors.Is(err, ErrInvalidPolygon) {
+		t.Errorf("Corridor() error = %v, want %v", err, ErrInvalidPolygon)
+	}
+}
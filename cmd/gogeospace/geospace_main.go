@@ -22,14 +22,14 @@ func main() {
 		coordinates = append(coordinates, &point.Point{Lat: lat, Lng: lng})
 	}
 
-	intersectedPolyPoints, err := geospace.GetIntersectedPolygonByPolygonAndCenterPointRadiusHaveriseDisc(coordinates, 26.43, -80.32, 7000)
+	intersectedPolys, err := geospace.GetIntersectedPolygonByPolygonAndCenterPointRadiusHaveriseDisc(point.Ring(coordinates), 26.43, -80.32, 7000)
 	if err != nil {
 		log.Println("Failed intersecting polygon with haversine disc")
 	}
 
-	if len(intersectedPolyPoints) > 0 {
+	for _, polygon := range intersectedPolys {
 		var transformedPoints string
-		for _, p := range intersectedPolyPoints {
+		for _, p := range polygon.Exterior {
 			transformedPoints += fmt.Sprintf("%f,%f|", p.Lat, p.Lng) // transform to expected format 26,80|29,81
 		}
 
@@ -40,14 +40,14 @@ func main() {
 		log.Println("Final with Harversine: ", transformedPoints)
 	}
 
-	intersectedPolyPoints, err = geospace.GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDisc(coordinates, 26.43, -80.32, 7000)
+	intersectedPolys, err = geospace.GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDisc(point.Ring(coordinates), 26.43, -80.32, 7000)
 	if err != nil {
 		log.Println("Failed intersecting polygon with vincenty disc")
 	}
 
-	if len(intersectedPolyPoints) > 0 {
+	for _, polygon := range intersectedPolys {
 		var transformedPoints string
-		for _, p := range intersectedPolyPoints {
+		for _, p := range polygon.Exterior {
 			transformedPoints += fmt.Sprintf("%f,%f|", p.Lat, p.Lng) // transform to expected format 26,80|29,81
 		}
 
@@ -0,0 +1,70 @@
+package gogeospace
+
+import (
+	"context"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+// operationContext bounds the context by the options timeout - GEOS calls
+// cannot be interrupted so operations check it between steps
+func operationContext(ctx context.Context, opts Options) (context.Context, context.CancelFunc) {
+	if opts.Timeout > 0 {
+		return context.WithTimeout(ctx, opts.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// checkVertexBudget rejects geometries with more vertices in total than the
+// options allow - counted after the options densify them
+func checkVertexBudget(opts Options, geometries ...point.Geometry) error {
+	if opts.MaxVertices <= 0 {
+		return nil
+	}
+
+	vertices := 0
+	for _, geometry := range geometries {
+		vertices += countVertices(geometry, opts)
+	}
+
+	if vertices > opts.MaxVertices {
+		return &VertexBudgetError{Vertices: vertices, MaxVertices: opts.MaxVertices}
+	}
+	return nil
+}
+
+// countVertices returns the vertices of every ring including holes, line and
+// point of a geometry once the options densify it
+func countVertices(geometry point.Geometry, opts Options) int {
+	switch g := geometry.(type) {
+	case *point.Point:
+		if g == nil {
+			return 0
+		}
+		return 1
+	case point.LineString:
+		return densifiedCount(g, opts.DensifyMeters, opts.Method, false)
+	case point.Polygonal:
+		vertices := 0
+		for _, polygon := range g.Polygons() {
+			if polygon == nil {
+				continue
+			}
+			for _, ring := range append([][]*point.Point{polygon.Exterior}, polygon.Interiors...) {
+				vertices += densifiedCount(openRing(ring), opts.DensifyMeters, opts.Method, true)
+			}
+		}
+		return vertices
+	case *point.GeometryCollection:
+		if g == nil {
+			return 0
+		}
+		vertices := countVertices(g.Polygons, opts) + len(g.Points)
+		for _, line := range g.LineStrings {
+			vertices += countVertices(line, opts)
+		}
+		return vertices
+	default:
+		return 0
+	}
+}
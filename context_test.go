@@ -0,0 +1,68 @@
+package gogeospace
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+func TestVertexBudgetCountsDensifiedVertices(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		err  error
+	}{
+		{name: "within budget", opts: Options{MaxVertices: 600}},
+		{name: "densified over budget", opts: Options{MaxVertices: 600, DensifyMeters: 100}, err: ErrVertexBudget},
+		{name: "densified within budget", opts: Options{MaxVertices: 100000, DensifyMeters: 100}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := IntersectPolygonWithDisc(box(0, 0, 1, 1), &point.Point{Lat: 0.5, Lng: 0.5}, 10000, test.opts)
+			if !errors.Is(err, test.err) {
+				t.Errorf("IntersectPolygonWithDisc() error = %v, want %v", err, test.err)
+			}
+		})
+	}
+
+	_, err := DoPolygonsIntersect(box(0, 0, 1, 1), box(0.5, 0.5, 2, 2), Options{MaxVertices: 10, DensifyMeters: 10})
+	if !errors.Is(err, ErrVertexBudget) {
+		t.Errorf("DoPolygonsIntersect() error = %v, want %v", err, ErrVertexBudget)
+	}
+}
+
+func TestTimeoutWithBadInput(t *testing.T) {
+	opts := Options{Timeout: time.Second}
+	returned := make(chan struct{})
+
+	go func() {
+		defer close(returned)
+
+		_, err := IntersectPolygonWithDiscContext(context.Background(), box(0, 0, 20, 20), &point.Point{Lat: 10, Lng: 10}, math.NaN(), opts)
+		if !errors.Is(err, ErrInvalidDisc) {
+			t.Errorf("IntersectPolygonWithDiscContext() error = %v, want %v", err, ErrInvalidDisc)
+		}
+
+		jobs := []DiscJob{
+			{Polygon: box(0, 0, 20, 20), Center: &point.Point{Lat: 10, Lng: 10}, Radius: 100000},
+			{Polygon: box(0, 0, 20, 20), Center: &point.Point{Lat: 10, Lng: 10}, Radius: math.Inf(1)},
+			{Polygon: box(0, 0, 20, 20), Center: &point.Point{Lat: math.NaN(), Lng: 10}, Radius: 100000},
+		}
+		results := IntersectDiscBatch(context.Background(), jobs, BatchOptions{Options: opts, Workers: 2})
+		if results[0].Err != nil || !errors.Is(results[1].Err, ErrInvalidDisc) || !errors.Is(results[2].Err, ErrInvalidDisc) {
+			t.Errorf("IntersectDiscBatch() errors = %v, %v, %v, want nil, %v, %v",
+				results[0].Err, results[1].Err, results[2].Err, ErrInvalidDisc, ErrInvalidDisc)
+		}
+	}()
+
+	select {
+	case <-returned:
+	case <-time.After(10 * opts.Timeout):
+		t.Fatal("operations with bad input did not return within their timeout")
+	}
+}
@@ -0,0 +1,41 @@
+package gogeospace
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+const (
+	// CORRIDOR_SEGMENT_METERS longest path segment buffered as a straight line -
+	// longer segments are split along the great circle first
+	CORRIDOR_SEGMENT_METERS = 10000.0
+)
+
+// Corridor returns the area within half width meters of a path such as a
+// delivery route - a smooth polygon around the polyline, split in two only
+// where it crosses the antimeridian, that feeds into the polygon intersection
+// functions. Long segments follow the great circle and the width is the
+// BufferMeters approximation - it drifts on routes far from their middle
+// Params:
+// Path polyline slice of lat,lng in degrees
+// HalfWidthMeters distance from the path to each side of the corridor
+// Opts optional settings such as flat instead of round ends with
+// BufferStyle.CapStyle geom.CapFlat
+func Corridor(path []*point.Point, halfWidthMeters float64, opts ...Options) (point.MultiPolygon, error) {
+	if !(halfWidthMeters > 0) || math.IsInf(halfWidthMeters, 0) {
+		return nil, fmt.Errorf("%w: corridor half width of %f meters must be positive", ErrInvalidDistance, halfWidthMeters)
+	}
+
+	for _, p := range path {
+		if p == nil {
+			return nil, &PolygonError{Coordinates: path, Reason: "nil point", Err: ErrInvalidPolygon}
+		}
+		if !validCoordinate(p) {
+			return nil, &PolygonError{Coordinates: path, Reason: ValidationIssue{Reason: InvalidCoordinate, Location: p}.String(), Err: ErrInvalidPolygon}
+		}
+	}
+
+	return BufferMeters(point.LineString(densifyPoints(path, CORRIDOR_SEGMENT_METERS, MethodHaversine, false)), halfWidthMeters, opts...)
+}
@@ -0,0 +1,59 @@
+package gogeospace
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+func TestCorridor(t *testing.T) {
+	// Round ends are polygons of DEFAULT_QUAD_SEGS segments per quarter
+	discArea := 2 * DEFAULT_QUAD_SEGS * math.Sin(math.Pi/(2*DEFAULT_QUAD_SEGS))
+	degree := math.Pi / 180 * haversine.EARTH_RADIUS_CONSTANT
+	width := 1000.0
+	flat := Options{BufferStyle: geom.BufferOpts{CapStyle: geom.CapFlat}}
+
+	tests := []struct {
+		name     string
+		path     []*point.Point
+		opts     Options
+		area     float64
+		polygons int
+	}{
+		{name: "straight path", path: []*point.Point{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 0.5}},
+			area: 2*width*0.5*degree + discArea*width*width, polygons: 1},
+		{name: "straight path with flat ends", path: []*point.Point{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 0.5}}, opts: flat,
+			area: 2 * width * 0.5 * degree, polygons: 1},
+		// The legs overlap in a square inside the turn and a quarter disc
+		// fills the outside
+		{name: "right angle turn with flat ends", path: []*point.Point{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 0.5}, {Lat: 0.5, Lng: 0.5}}, opts: flat,
+			area: 2*width*degree - width*width + discArea/4*width*width, polygons: 1},
+		{name: "across the antimeridian", path: []*point.Point{{Lat: 0, Lng: 179.75}, {Lat: 0, Lng: -179.75}}, opts: flat,
+			area: 2 * width * 0.5 * degree, polygons: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			corridor, err := Corridor(test.path, width, test.opts)
+			if err != nil {
+				t.Fatalf("Corridor() error = %v", err)
+			}
+			if len(corridor) != test.polygons {
+				t.Errorf("Corridor() = %d polygons, want %d", len(corridor), test.polygons)
+			}
+			if area := areaOf(t, corridor); math.Abs(area-test.area) > 1e-3*test.area {
+				t.Errorf("Corridor() area = %v, want %v", area, test.area)
+			}
+		})
+	}
+
+	for _, halfWidth := range []float64{0, -10, math.NaN(), math.Inf(1)} {
+		if _, err := Corridor([]*point.Point{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}}, halfWidth); !errors.Is(err, ErrInvalidDistance) {
+			t.Errorf("Corridor(%v) error = %v, want %v", halfWidth, err, ErrInvalidDistance)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package gogeospace
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/vincenty"
+)
+
+// Densify inserts points along every edge of polygons so no edge is longer
+// than max segment meters - long edges such as state lines then follow the
+// geodesic instead of a straight line in the plane polygon operations run in
+// Params:
+// Polygonal rings, polygons or multi polygons of lat,lng in degrees
+// MaxSegmentMeters longest edge after densifying in meters
+// Method the Earth model the geodesic is interpolated on
+func Densify(polygonal point.Polygonal, maxSegmentMeters float64, method Method) (point.MultiPolygon, error) {
+	if !(maxSegmentMeters > 0) || math.IsInf(maxSegmentMeters, 0) {
+		return nil, fmt.Errorf("%w: densify segment of %f meters must be positive", ErrInvalidDistance, maxSegmentMeters)
+	}
+
+	polygons, err := polygonsOf(polygonal, Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapRings(polygons, func(ring []*point.Point) []*point.Point {
+		return closeRing(densifyPoints(openRing(ring), maxSegmentMeters, method, true))
+	}), nil
+}
+
+// densifyPoints inserts points along the geodesic of every segment longer
+// than max segment meters - closed includes the edge from the last point back
+// to the first
+func densifyPoints(points []*point.Point, maxSegmentMeters float64, method Method, closed bool) []*point.Point {
+	if len(points) < 2 || maxSegmentMeters <= 0 {
+		return points
+	}
+
+	n := len(points)
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+
+	densified := make([]*point.Point, 0, n)
+	for i := 0; i < segments; i++ {
+		p1, p2 := points[i], points[(i+1)%n]
+		densified = append(densified, p1)
+
+		steps, distance, bearing := segmentSteps(p1, p2, maxSegmentMeters, method)
+		for step := 1; step < steps; step++ {
+			fraction := float64(step) / float64(steps)
+
+			var lat, lng float64
+			if method == MethodHaversine {
+				lat, lng = haversine.Intermediate(p1.Lat, p1.Lng, p2.Lat, p2.Lng, fraction)
+			} else {
+				lat, lng = vincenty.Destination(p1.Lat, p1.Lng, distance*fraction, bearing)
+			}
+			densified = append(densified, &point.Point{Lat: lat, Lng: lng})
+		}
+	}
+
+	if !closed {
+		densified = append(densified, points[n-1])
+	}
+	return densified
+}
+
+// densifiedCount returns the number of points densifyPoints returns without
+// creating them - nil points are counted as they are
+func densifiedCount(points []*point.Point, maxSegmentMeters float64, method Method, closed bool) int {
+	if len(points) < 2 || maxSegmentMeters <= 0 {
+		return len(points)
+	}
+
+	n := len(points)
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+
+	count := n
+	for i := 0; i < segments; i++ {
+		p1, p2 := points[i], points[(i+1)%n]
+		if p1 == nil || p2 == nil {
+			continue
+		}
+		if steps, _, _ := segmentSteps(p1, p2, maxSegmentMeters, method); steps > 1 {
+			count += steps - 1
+		}
+	}
+	return count
+}
+
+// segmentSteps returns the number of pieces densifying splits the segment from
+// p1 to p2 into together with its length in meters and starting bearing in
+// degrees - the bearing is only set for the vincenty method
+func segmentSteps(p1, p2 *point.Point, maxSegmentMeters float64, method Method) (int, float64, float64) {
+	distance, bearing := 0.0, 0.0
+	if method == MethodHaversine {
+		distance = haversine.Distance(p1.Lat, p1.Lng, p2.Lat, p2.Lng)
+	} else {
+		distance, bearing = vincenty.Inverse(p1.Lat, p1.Lng, p2.Lat, p2.Lng)
+	}
+
+	return int(math.Ceil(distance / maxSegmentMeters)), distance, bearing
+}
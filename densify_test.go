@@ -0,0 +1,83 @@
+package gogeospace
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/vincenty"
+)
+
+func TestDensify(t *testing.T) {
+	// One degree of the equator is 111319.49 meters on the ellipsoid and
+	// 111195.08 on the sphere, one of a meridian 110574.39 on the ellipsoid
+	tests := []struct {
+		name     string
+		ring     point.Ring
+		max      float64
+		method   Method
+		segments int
+	}{
+		{name: "degree box on the ellipsoid", ring: box(0, 0, 1, 1), max: 10000, method: MethodVincenty, segments: 4 * 12},
+		{name: "degree box on the sphere", ring: box(0, 0, 1, 1), max: 10000, method: MethodHaversine, segments: 4 * 12},
+		{name: "equator edge just above the max", ring: point.Ring{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 0.001, Lng: 0.5}}, max: 111319, method: MethodVincenty, segments: 2 + 2},
+		{name: "equator edge just below the max", ring: point.Ring{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 0.001, Lng: 0.5}}, max: 111320, method: MethodVincenty, segments: 1 + 2},
+		{name: "short edges kept", ring: box(0, 0, 0.01, 0.01), max: 10000, method: MethodVincenty, segments: 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			densified, err := Densify(test.ring, test.max, test.method)
+			if err != nil {
+				t.Fatalf("Densify() error = %v", err)
+			}
+			if len(densified) != 1 {
+				t.Fatalf("Densify() = %d polygons, want 1", len(densified))
+			}
+			ring := densified[0].Exterior
+			if segments := len(ring) - 1; segments != test.segments {
+				t.Errorf("Densify() = %d segments, want %d", segments, test.segments)
+			}
+
+			// Original vertices are kept in order and no segment is too long
+			next := 0
+			for i, p := range ring {
+				if next < len(test.ring) && *p == *test.ring[next] {
+					next++
+				}
+				if i == 0 {
+					continue
+				}
+				length, _ := vincenty.Inverse(ring[i-1].Lat, ring[i-1].Lng, p.Lat, p.Lng)
+				if test.method == MethodHaversine {
+					length = haversine.Distance(ring[i-1].Lat, ring[i-1].Lng, p.Lat, p.Lng)
+				}
+				if length > test.max*(1+1e-9) {
+					t.Errorf("Densify() segment %d = %v meters, want at most %v", i, length, test.max)
+				}
+			}
+			if next != len(test.ring) {
+				t.Errorf("Densify() kept %d of %d vertices in order", next, len(test.ring))
+			}
+		})
+	}
+
+	// Inserted points follow the geodesic - evenly along the equator
+	densified, err := Densify(box(0, 0, 1, 1), 10000, MethodVincenty)
+	if err != nil {
+		t.Fatalf("Densify() error = %v", err)
+	}
+	for k, p := range densified[0].Exterior[:13] {
+		if math.Abs(p.Lat) > 1e-12 || math.Abs(p.Lng-float64(k)/12) > 1e-9 {
+			t.Errorf("Densify() point %d = %+v, want lat 0 lng %v", k, *p, float64(k)/12)
+		}
+	}
+
+	for _, max := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		if _, err := Densify(box(0, 0, 1, 1), max, MethodVincenty); !errors.Is(err, ErrInvalidDistance) {
+			t.Errorf("Densify(%v) error = %v, want %v", max, err, ErrInvalidDistance)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package gogeospace
+
+import (
+	"context"
+	"math"
+
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/vincenty"
+)
+
+// DiscGenerator creates the ring of a disc around a center point - both the
+// haversine and vincenty packages provide one
+// Params:
+// Lat center point lat in degrees
+// Lng center point lng in degrees
+// Radius off center point in meters
+type DiscGenerator interface {
+	CreateDisc(lat, lng, radius float64) []*point.Point
+}
+
+// IntersectPolygonWithDisc returns the intersection of an individual polygon
+// and a disc created by the options generator around the passed in center
+// point and radius - one polygon per disjoint part, each with its exterior
+// ring and interior rings (holes), plus the lines and points left where the
+// disc only touches the polygon unless only areal parts are kept
+// Params:
+// Polygonal ring, polygon with holes or multi polygon of lat,lng in degrees
+// Center point lat,lng in degrees
+// Radius off center point to create the disc in meters
+// Opts settings such as the disc generator (vincenty by default) and projection
+func IntersectPolygonWithDisc(
+	polygonal point.Polygonal,
+	center *point.Point,
+	radius float64,
+	opts Options) (*point.GeometryCollection, error) {
+
+	return IntersectPolygonWithDiscContext(context.Background(), polygonal, center, radius, opts)
+}
+
+// IntersectPolygonWithDiscContext is IntersectPolygonWithDisc giving up with
+// ctx.Err() between disc generation, geometry building and the intersection
+// once the context is done
+func IntersectPolygonWithDiscContext(
+	ctx context.Context,
+	polygonal point.Polygonal,
+	center *point.Point,
+	radius float64,
+	opts Options) (collection *point.GeometryCollection, err error) {
+
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	ctx, cancel := operationContext(ctx, opts)
+	defer cancel()
+
+	if err := checkDisc(center, radius); err != nil {
+		return nil, err
+	}
+
+	if polygonal == nil {
+		return nil, &PolygonError{Reason: "nil polygon", Err: ErrInvalidPolygon}
+	}
+
+	if err := checkVertexBudget(opts, polygonal); err != nil {
+		return nil, err
+	}
+
+	f, err := newFrame(opts, center.Lat, center.Lng, pointsOf(polygonal)...)
+	if err != nil {
+		return nil, err
+	}
+
+	dotPolygon, err := getGeosGeometry(polygonal, f, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if dotPolygon == nil {
+		return nil, &PolygonError{Reason: "nil geometric poly shape from incoming boundary coordinates", Err: ErrInvalidPolygon}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	polyCoordinates, err := createDisc(center, radius, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVertexBudget(opts, polygonal, point.Ring(polyCoordinates)); err != nil {
+		return nil, err
+	}
+
+	if err := f.checkDomain(polyCoordinates); err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return processPolyCoordinates(ctx, polyCoordinates, dotPolygon, f, opts)
+}
+
+// checkDisc rejects a center point and radius that cannot form a disc
+func checkDisc(center *point.Point, radius float64) error {
+	if center == nil {
+		return &DiscError{Radius: radius, Reason: "nil center point"}
+	}
+
+	if !validCoordinate(center) {
+		return &DiscError{Center: center, Radius: radius, Reason: "center point must be a finite lat,lng with lat within ±90"}
+	}
+
+	if !(radius > 0) || math.IsInf(radius, 0) {
+		return &DiscError{Center: center, Radius: radius, Reason: "radius must be a positive finite number"}
+	}
+
+	return nil
+}
+
+// createDisc creates the disc ring with the options generator - vincenty by
+// default
+func createDisc(center *point.Point, radius float64, opts Options) ([]*point.Point, error) {
+	generator := opts.Generator
+	if generator == nil {
+		generator = vincenty.Generator{}
+	}
+
+	// C lib has problems with gaps around polygon edges
+	polyCoordinates := generator.CreateDisc(center.Lat, center.Lng, radius)
+
+	if polyCoordinates == nil {
+		return nil, &DiscError{Center: center, Radius: radius, Reason: "nil disc from generator - such as a disc reaching past both poles"}
+	}
+
+	return polyCoordinates, nil
+}
@@ -0,0 +1,219 @@
+package disc
+
+import (
+	"math"
+
+	"github.com/jdejesus007/gogeospace/constants"
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/utils"
+)
+
+const (
+	// MIN_RING_STEPS fewest vertices that still form a ring
+	MIN_RING_STEPS = 3
+	// MIN_STEPS fewest vertices around a disc derived from a max error
+	MIN_STEPS = 8
+	// MAX_STEPS most vertices around a disc - bounds tiny tolerances on huge radii
+	MAX_STEPS = 100000
+)
+
+// Options control how many vertices approximate a disc - with neither set the
+// disc has constants.NUM_STEPS_PRECISION vertices
+type Options struct {
+	// Steps is an explicit vertex count - takes precedence over MaxErrorMeters
+	Steps int
+	// MaxErrorMeters is the largest allowed deviation in meters between a
+	// chord of the disc ring and the arc it replaces - the vertex count is
+	// derived from it
+	MaxErrorMeters float64
+}
+
+// Resolve returns the first of optional disc options - the zero options when
+// none are given
+func Resolve(opts ...Options) Options {
+	if len(opts) == 0 {
+		return Options{}
+	}
+	return opts[0]
+}
+
+// Valid returns true if lat, lng in degrees is a finite center within ±90 lat
+// and every size in meters is positive and finite - shapes built from
+// anything else are nil
+func Valid(lat, lng float64, sizes ...float64) bool {
+	if math.IsNaN(lat) || math.IsNaN(lng) || math.IsInf(lng, 0) || math.Abs(lat) > 90.0 {
+		return false
+	}
+	for _, size := range sizes {
+		if !(size > 0) || math.IsInf(size, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// Steps returns the vertex count for a disc and the achieved chord-to-arc
+// error bound in meters
+// Params:
+// Radius of the disc in meters
+// EarthRadius of the Earth model in meters
+// Opts explicit step count or max error
+func Steps(radius, earthRadius float64, opts Options) (int, float64) {
+	// Radius of the small circle the disc ring lies on
+	circleRadius := math.Abs(earthRadius * math.Sin(radius/earthRadius))
+
+	steps := int(constants.NUM_STEPS_PRECISION)
+	switch {
+	case opts.Steps > 0:
+		steps = opts.Steps
+		if steps < MIN_RING_STEPS {
+			steps = MIN_RING_STEPS
+		}
+	case opts.MaxErrorMeters > 0:
+		steps = MIN_STEPS
+		if opts.MaxErrorMeters < circleRadius {
+			// sagitta = r * (1 - cos(pi / n)) solved for n
+			steps = int(math.Ceil(math.Pi / math.Acos(1.0-opts.MaxErrorMeters/circleRadius)))
+		}
+		if steps < MIN_STEPS {
+			steps = MIN_STEPS
+		}
+	}
+
+	if steps > MAX_STEPS {
+		steps = MAX_STEPS
+	}
+
+	return steps, circleRadius * (1.0 - math.Cos(math.Pi/float64(steps)))
+}
+
+// EnclosePole turns a ring winding around a geographic pole into a ring that
+// is valid in a lat,lng plane - the ring is cut where it crosses the meridian
+// opposite lng and closed along the pole parallel so the pole lies inside
+// Params:
+// Ring open ring of lat,lng in degrees winding once around the pole
+// Pole lat of the enclosed pole - 90 or -90
+// Lng center of the lng range of the result - the cut lies at lng ±180
+func EnclosePole(ring []*point.Point, pole, lng float64) []*point.Point {
+	n := len(ring)
+	unwrapped := make([]float64, n)
+	for i, p := range ring {
+		unwrapped[i] = utils.UnwrapLongitude(p.Lng, lng)
+	}
+
+	// Find the edge jumping across the cut
+	start := -1
+	for i := 0; i < n; i++ {
+		if math.Abs(unwrapped[i]-unwrapped[(i+n-1)%n]) > 180.0 {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return ring
+	}
+
+	enclosed := make([]*point.Point, 0, n+4)
+	for i := 0; i < n; i++ {
+		j := (start + i) % n
+		enclosed = append(enclosed, &point.Point{Lat: ring[j].Lat, Lng: unwrapped[j]})
+	}
+
+	// Interpolate where the jumping edge from a to b crosses the cut
+	a, b := enclosed[n-1], enclosed[0]
+	endLng := lng - 180.0
+	if a.Lng > lng {
+		endLng = lng + 180.0
+	}
+	startLng := 2.0*lng - endLng
+	bLng := b.Lng + endLng - startLng
+	crossLat := a.Lat
+	if bLng != a.Lng {
+		crossLat += (endLng - a.Lng) / (bLng - a.Lng) * (b.Lat - a.Lat)
+	}
+
+	return append(enclosed,
+		&point.Point{Lat: crossLat, Lng: endLng},
+		&point.Point{Lat: pole, Lng: endLng},
+		&point.Point{Lat: pole, Lng: startLng},
+		&point.Point{Lat: crossLat, Lng: startLng},
+	)
+}
+
+// Destination returns the point reached from lat, lng in degrees after
+// distance meters starting at bearing in degrees on an Earth model
+type Destination func(lat, lng, distance, bearing float64) (float64, float64)
+
+// Sector returns the ring of a wedge from the center out to radius between
+// two bearings - clockwise from start to end bearing, a full disc when they
+// cover the whole circle. Nil unless the center, radius and bearings are Valid
+// finite numbers
+// Params:
+// Lat, Lng center of the wedge in degrees
+// Radius of the wedge in meters
+// StartBearing, EndBearing in degrees clockwise from north
+// EarthRadius of the Earth model in meters
+// Opts vertex count or max error of the whole circle
+// Destination the Earth model of the wedge
+func Sector(lat, lng, radius, startBearing, endBearing, earthRadius float64, opts Options, destination Destination) []*point.Point {
+	if !Valid(lat, lng, radius) || math.IsNaN(startBearing-endBearing) || math.IsInf(startBearing-endBearing, 0) {
+		return nil
+	}
+
+	steps, _ := Steps(radius, earthRadius, opts)
+
+	sweep := math.Mod(endBearing-startBearing, 360.0)
+	if sweep <= 0 {
+		sweep += 360.0
+	}
+
+	arcSteps := int(math.Ceil(float64(steps) * sweep / 360.0))
+	vertices := arcSteps
+
+	var coordinates []*point.Point
+	if sweep < 360.0 {
+		if arcSteps < 2 {
+			arcSteps = 2
+		}
+		// Both edge bearings are vertices and the edges meet at the center
+		vertices = arcSteps + 1
+		coordinates = append(coordinates, &point.Point{Lat: lat, Lng: lng})
+	}
+
+	for i := 0; i < vertices; i++ {
+		lat2, lng2 := destination(lat, lng, radius, startBearing+sweep*float64(i)/float64(arcSteps))
+		coordinates = append(coordinates, &point.Point{Lat: lat2, Lng: lng2})
+	}
+	return coordinates
+}
+
+// Ellipse returns the ring of an ellipse around the center with its semi-major
+// axis along the azimuth - every vertex lies at its elliptical distance along
+// its bearing from the center. Nil unless the center, semi-axes and azimuth
+// are Valid finite numbers
+// Params:
+// Lat, Lng center of the ellipse in degrees
+// SemiMajor, SemiMinor axes in meters
+// Azimuth of the semi-major axis in degrees clockwise from north
+// EarthRadius of the Earth model in meters
+// Opts vertex count or max error
+// Destination the Earth model of the ellipse
+func Ellipse(lat, lng, semiMajor, semiMinor, azimuth, earthRadius float64, opts Options, destination Destination) []*point.Point {
+	if !Valid(lat, lng, semiMajor, semiMinor) || math.IsNaN(azimuth) || math.IsInf(azimuth, 0) {
+		return nil
+	}
+
+	steps, _ := Steps(math.Max(semiMajor, semiMinor), earthRadius, opts)
+
+	coordinates := make([]*point.Point, 0, steps)
+	for i := 0; i < steps; i++ {
+		t := 2.0 * math.Pi * float64(i) / float64(steps)
+		along := semiMajor * math.Cos(t)
+		across := semiMinor * math.Sin(t)
+
+		bearing := azimuth + utils.RadToDegrees(math.Atan2(across, along))
+		lat2, lng2 := destination(lat, lng, math.Hypot(along, across), bearing)
+		coordinates = append(coordinates, &point.Point{Lat: lat2, Lng: lng2})
+	}
+	return coordinates
+}
@@ -0,0 +1,307 @@
+package gogeospace
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/constants"
+	"github.com/jdejesus007/gogeospace/disc"
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/vincenty"
+)
+
+func TestIntersectPolygonWithDiscKeepsHoles(t *testing.T) {
+	polygon := &point.Polygon{
+		Exterior:  box(0, 0, 2, 2),
+		Interiors: [][]*point.Point{box(0.9, 0.9, 1.1, 1.1)},
+	}
+
+	tests := []struct {
+		name      string
+		polygonal point.Polygonal
+	}{
+		{name: "polygon", polygonal: polygon},
+		{name: "multi polygon", polygonal: point.MultiPolygon{polygon, {Exterior: box(10, 10, 11, 11)}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collection, err := IntersectPolygonWithDisc(test.polygonal, &point.Point{Lat: 1, Lng: 1}, 50000, Options{})
+			if err != nil {
+				t.Fatalf("IntersectPolygonWithDisc() error = %v", err)
+			}
+			if len(collection.Polygons) != 1 || len(collection.Polygons[0].Interiors) != 1 {
+				t.Fatalf("IntersectPolygonWithDisc() = %d polygons, want 1 with a hole", len(collection.Polygons))
+			}
+
+			multiPolygon, err := GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDisc(test.polygonal, 1, 1, 50000)
+			if err != nil {
+				t.Fatalf("GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDisc() error = %v", err)
+			}
+			if len(multiPolygon) != 1 || len(multiPolygon[0].Interiors) != 1 {
+				t.Errorf("GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDisc() = %d polygons, want 1 with a hole", len(multiPolygon))
+			}
+		})
+	}
+}
+
+func TestIntersectPolygonWithInvalidDisc(t *testing.T) {
+	tests := []struct {
+		name   string
+		center *point.Point
+		radius float64
+	}{
+		{name: "nil center", radius: 1000},
+		{name: "zero radius", center: &point.Point{Lat: 10, Lng: 10}},
+		{name: "negative radius", center: &point.Point{Lat: 10, Lng: 10}, radius: -1000},
+		{name: "NaN radius", center: &point.Point{Lat: 10, Lng: 10}, radius: math.NaN()},
+		{name: "infinite radius", center: &point.Point{Lat: 10, Lng: 10}, radius: math.Inf(1)},
+		{name: "NaN lat", center: &point.Point{Lat: math.NaN(), Lng: 10}, radius: 1000},
+		{name: "infinite lng", center: &point.Point{Lat: 10, Lng: math.Inf(-1)}, radius: 1000},
+		{name: "lat past pole", center: &point.Point{Lat: 91, Lng: 10}, radius: 1000},
+	}
+
+	prepared, err := NewPreparedPolygon(box(0, 0, 20, 20))
+	if err != nil {
+		t.Fatalf("NewPreparedPolygon() error = %v", err)
+	}
+	defer prepared.Close()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := IntersectPolygonWithDisc(box(0, 0, 20, 20), test.center, test.radius, Options{}); !errors.Is(err, ErrInvalidDisc) {
+				t.Errorf("IntersectPolygonWithDisc() error = %v, want %v", err, ErrInvalidDisc)
+			}
+			if _, err := prepared.IntersectDisc(test.center, test.radius); !errors.Is(err, ErrInvalidDisc) {
+				t.Errorf("IntersectDisc() error = %v, want %v", err, ErrInvalidDisc)
+			}
+		})
+	}
+}
+
+func TestCalculateVincentyCoordinateReturnsOnNaN(t *testing.T) {
+	lat, lng, _ := vincenty.CalculateVincentyCoordinate(10, 10, math.NaN(), 45)
+	if !math.IsNaN(lat) || !math.IsNaN(lng) {
+		t.Errorf("CalculateVincentyCoordinate() = %v, %v, want NaN", lat, lng)
+	}
+}
+
+func TestIntersectPolygonWithDiscSeparatesParts(t *testing.T) {
+	// Two arms joined along the south
+	u := point.Ring{
+		{Lat: 0, Lng: 0}, {Lat: 0, Lng: 3}, {Lat: 3, Lng: 3}, {Lat: 3, Lng: 2},
+		{Lat: 1, Lng: 2}, {Lat: 1, Lng: 1}, {Lat: 3, Lng: 1}, {Lat: 3, Lng: 0},
+	}
+
+	tests := []struct {
+		name     string
+		center   *point.Point
+		polygons int
+	}{
+		{name: "both arms", center: &point.Point{Lat: 2, Lng: 1.5}, polygons: 2},
+		{name: "one arm", center: &point.Point{Lat: 2, Lng: 0.5}, polygons: 1},
+		{name: "arms and their base", center: &point.Point{Lat: 1, Lng: 1.5}, polygons: 1},
+		{name: "outside", center: &point.Point{Lat: 10, Lng: 10}, polygons: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collection, err := IntersectPolygonWithDisc(u, test.center, 100000, Options{})
+			if err != nil {
+				t.Fatalf("IntersectPolygonWithDisc() error = %v", err)
+			}
+			if len(collection.Polygons) != test.polygons {
+				t.Fatalf("IntersectPolygonWithDisc() = %d polygons, want %d", len(collection.Polygons), test.polygons)
+			}
+
+			// Every part is its own closed ring inside the U
+			for _, polygon := range collection.Polygons {
+				ring := polygon.Exterior
+				if first, last := ring[0], ring[len(ring)-1]; *first != *last {
+					t.Errorf("ring from %v to %v, want closed", *first, *last)
+				}
+				for _, p := range ring {
+					if p.Lat < -1e-9 || (p.Lat > 1+1e-9 && p.Lng > 1+1e-9 && p.Lng < 2-1e-9) {
+						t.Errorf("point %v outside the U", *p)
+					}
+				}
+			}
+		})
+	}
+}
+
+// fixedGenerator returns the same ring whatever the center and radius
+type fixedGenerator point.Ring
+
+func (g fixedGenerator) CreateDisc(lat, lng, radius float64) []*point.Point {
+	return g
+}
+
+func TestIntersectPolygonWithDiscKeepsExactCoordinates(t *testing.T) {
+	// Sums without an exact decimal form survive the round trip bit for bit
+	tenth, fifth := 0.1, 0.2
+	south, west, north, east := tenth+fifth, tenth*7, tenth*8+fifth/3, fifth+1.1
+	inside := box(south, west, north, east)
+
+	collection, err := IntersectPolygonWithDisc(box(0, 0, 2, 2), &point.Point{Lat: 0.5, Lng: 1}, 1000, Options{Generator: fixedGenerator(inside)})
+	if err != nil {
+		t.Fatalf("IntersectPolygonWithDisc() error = %v", err)
+	}
+	if len(collection.Polygons) != 1 {
+		t.Fatalf("IntersectPolygonWithDisc() = %d polygons, want 1", len(collection.Polygons))
+	}
+
+	want := map[point.Point]bool{}
+	for _, p := range inside {
+		want[*p] = true
+	}
+	exterior := collection.Polygons[0].Exterior
+	if len(exterior) != len(inside)+1 {
+		t.Fatalf("IntersectPolygonWithDisc() exterior = %d points, want %d", len(exterior), len(inside)+1)
+	}
+	for _, p := range exterior {
+		if !want[*p] {
+			t.Errorf("IntersectPolygonWithDisc() point %v is not a corner of %v", *p, inside)
+		}
+	}
+}
+
+// recordingGenerator records the disc it was asked for
+type recordingGenerator struct {
+	calls *[][3]float64
+}
+
+func (g recordingGenerator) CreateDisc(lat, lng, radius float64) []*point.Point {
+	*g.calls = append(*g.calls, [3]float64{lat, lng, radius})
+	return haversine.Generator{}.CreateDisc(lat, lng, radius)
+}
+
+func TestIntersectPolygonWithDiscGenerators(t *testing.T) {
+	center := &point.Point{Lat: 0.5, Lng: 0.5}
+	radius := 10000.0
+
+	var calls [][3]float64
+	if _, err := IntersectPolygonWithDisc(box(0, 0, 1, 1), center, radius, Options{Generator: recordingGenerator{calls: &calls}}); err != nil {
+		t.Fatalf("IntersectPolygonWithDisc() error = %v", err)
+	}
+	if len(calls) != 1 || calls[0] != [3]float64{center.Lat, center.Lng, radius} {
+		t.Errorf("CreateDisc() calls = %v, want one for %v, %v", calls, *center, radius)
+	}
+
+	// A disc inside the polygon comes back whole - the area of a regular
+	// polygon of constants.NUM_STEPS_PRECISION vertices on the radius
+	steps := float64(constants.NUM_STEPS_PRECISION)
+	inscribed := steps / 2 * radius * radius * math.Sin(2*math.Pi/steps)
+
+	tests := []struct {
+		name   string
+		opts   Options
+		method Method
+	}{
+		{name: "default vincenty", opts: Options{}, method: MethodVincenty},
+		{name: "vincenty", opts: Options{Generator: vincenty.Generator{}}, method: MethodVincenty},
+		{name: "haversine", opts: Options{Generator: haversine.Generator{}}, method: MethodHaversine},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collection, err := IntersectPolygonWithDisc(box(0, 0, 1, 1), center, radius, test.opts)
+			if err != nil {
+				t.Fatalf("IntersectPolygonWithDisc() error = %v", err)
+			}
+			if len(collection.Polygons) != 1 || len(collection.Polygons[0].Exterior) != int(steps)+1 {
+				t.Fatalf("IntersectPolygonWithDisc() = %d polygons, want 1 of %v points", len(collection.Polygons), steps+1)
+			}
+			area, err := Area(collection.Polygons, test.method)
+			if err != nil || math.Abs(area-inscribed) > 1e-4*inscribed {
+				t.Errorf("Area() = %v, %v, want %v", area, err, inscribed)
+			}
+		})
+	}
+
+	defaults, errDefault := IntersectPolygonWithDisc(box(0, 0, 1, 1), center, radius, Options{})
+	explicit, errVincenty := IntersectPolygonWithDisc(box(0, 0, 1, 1), center, radius, Options{Generator: vincenty.Generator{}})
+	if errDefault != nil || errVincenty != nil || !reflect.DeepEqual(defaults, explicit) {
+		t.Errorf("IntersectPolygonWithDisc() default generator is not vincenty - errors %v, %v", errDefault, errVincenty)
+	}
+}
+
+func TestDiscSteps(t *testing.T) {
+	tests := []struct {
+		name   string
+		radius float64
+		opts   disc.Options
+		steps  int
+		bound  float64
+	}{
+		{name: "default", radius: 1000, steps: int(constants.NUM_STEPS_PRECISION), bound: 1000 * (1 - math.Cos(math.Pi/float64(constants.NUM_STEPS_PRECISION)))},
+		{name: "explicit steps", radius: 1000, opts: disc.Options{Steps: 4}, steps: 4, bound: 292.893},
+		{name: "too few steps", radius: 1000, opts: disc.Options{Steps: 2}, steps: disc.MIN_RING_STEPS, bound: 500},
+		{name: "steps before max error", radius: 1000, opts: disc.Options{Steps: 4, MaxErrorMeters: 1}, steps: 4, bound: 292.893},
+		{name: "one meter on one kilometer", radius: 1000, opts: disc.Options{MaxErrorMeters: 1}, steps: 71, bound: 0.979},
+		{name: "one meter on ten kilometers", radius: 10000, opts: disc.Options{MaxErrorMeters: 1}, steps: 223, bound: 0.992},
+		{name: "error past the radius", radius: 1000, opts: disc.Options{MaxErrorMeters: 5000}, steps: disc.MIN_STEPS, bound: 1000 * (1 - math.Cos(math.Pi/disc.MIN_STEPS))},
+		{name: "tiny error", radius: 1000000, opts: disc.Options{MaxErrorMeters: 1e-9}, steps: disc.MAX_STEPS},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			steps, bound := disc.Steps(test.radius, haversine.EARTH_RADIUS_CONSTANT, test.opts)
+			if steps != test.steps {
+				t.Errorf("Steps() = %d, want %d", steps, test.steps)
+			}
+			if test.bound > 0 && math.Abs(bound-test.bound) > 1e-3 {
+				t.Errorf("Steps() bound = %v, want %v", bound, test.bound)
+			}
+			if test.opts.MaxErrorMeters > 0 && test.opts.Steps == 0 && steps < disc.MAX_STEPS && bound > test.opts.MaxErrorMeters {
+				t.Errorf("Steps() bound = %v over the max error %v", bound, test.opts.MaxErrorMeters)
+			}
+
+			coordinates, generatorBound := haversine.CreateDiscWithErrorBound(10, 20, test.radius, test.opts)
+			if len(coordinates) != test.steps || generatorBound != bound {
+				t.Errorf("CreateDiscWithErrorBound() = %d points, bound %v, want %d, %v", len(coordinates), generatorBound, test.steps, bound)
+			}
+		})
+	}
+}
+
+func TestIntersectPolygonWithDiscResultTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		disc     point.Ring
+		areaOnly bool
+		polygons int
+		lines    int
+		points   int
+	}{
+		{name: "overlap", disc: box(0.5, 0.5, 1.5, 1.5), polygons: 1},
+		{name: "shared edge", disc: box(0.2, 1, 0.6, 2), lines: 1},
+		{name: "shared corner", disc: box(1, 1, 2, 2), points: 1},
+		{name: "shared corner of areas only", disc: box(1, 1, 2, 2), areaOnly: true},
+		{name: "overlap and shared corner", disc: point.Ring{
+			{Lat: 0.4, Lng: -0.6}, {Lat: 0.4, Lng: 0.5}, {Lat: 0.6, Lng: 0.5}, {Lat: 0.6, Lng: -0.2}, {Lat: 1.2, Lng: -0.2},
+			{Lat: 1.2, Lng: 0.9}, {Lat: 1, Lng: 1}, {Lat: 1.2, Lng: 1.1}, {Lat: 1.2, Lng: 1.6}, {Lat: 1.6, Lng: 1.6}, {Lat: 1.6, Lng: -0.6},
+		}, polygons: 1, points: 1},
+		{name: "disjoint", disc: box(5, 5, 6, 6)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collection, err := IntersectPolygonWithDisc(box(0, 0, 1, 1), &point.Point{Lat: 1, Lng: 1}, 1000,
+				Options{Generator: fixedGenerator(test.disc), AreaOnly: test.areaOnly})
+			if err != nil {
+				t.Fatalf("IntersectPolygonWithDisc() error = %v", err)
+			}
+			if len(collection.Polygons) != test.polygons || len(collection.LineStrings) != test.lines || len(collection.Points) != test.points {
+				t.Errorf("IntersectPolygonWithDisc() = %d polygons, %d lines, %d points, want %d, %d, %d",
+					len(collection.Polygons), len(collection.LineStrings), len(collection.Points), test.polygons, test.lines, test.points)
+			}
+			if empty := test.polygons+test.lines+test.points == 0; collection.IsEmpty() != empty {
+				t.Errorf("IsEmpty() = %v, want %v", collection.IsEmpty(), empty)
+			}
+		})
+	}
+}
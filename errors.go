@@ -0,0 +1,177 @@
+package gogeospace
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+var (
+	// ErrInvalidPolygon coordinates do not form a valid polygon
+	ErrInvalidPolygon = errors.New("invalid polygon")
+	// ErrTooFewPoints coordinates have fewer than three distinct points
+	ErrTooFewPoints = errors.New("too few points to form a polygon")
+	// ErrInvalidDisc center point or radius do not form a disc
+	ErrInvalidDisc = errors.New("invalid disc")
+	// ErrUnsupportedGeometryType GEOS returned a geometry type that cannot be
+	// converted to points
+	ErrUnsupportedGeometryType = errors.New("unsupported geometry type")
+	// ErrGEOSPanic the GEOS C library panicked during an operation
+	ErrGEOSPanic = errors.New("GEOS panic")
+	// ErrClosed a prepared polygon was used after Close
+	ErrClosed = errors.New("prepared polygon closed")
+	// ErrInvalidDistance a buffer or corridor distance is not a finite number
+	// of meters it can be built with
+	ErrInvalidDistance = errors.New("invalid distance")
+	// ErrVertexBudget inputs and generated shapes have more vertices than the
+	// options allow
+	ErrVertexBudget = errors.New("vertex budget exceeded")
+	// ErrOutsideProjection a point lies where the options projection cannot
+	// map it
+	ErrOutsideProjection = errors.New("outside projection domain")
+)
+
+// PolygonError reports coordinates that cannot be used as a polygon - matches
+// ErrInvalidPolygon or ErrTooFewPoints with errors.Is
+type PolygonError struct {
+	// Coordinates the offending incoming coordinates
+	Coordinates []*point.Point
+	// Reason why the coordinates were rejected
+	Reason string
+	// Err the matching sentinel error
+	Err error
+}
+
+func (e *PolygonError) Error() string {
+	return fmt.Sprintf("%v: %s - incoming coordinates: %s", e.Err, e.Reason, formatPoints(e.Coordinates))
+}
+
+func (e *PolygonError) Unwrap() error {
+	return e.Err
+}
+
+// DiscError reports a center point and radius that cannot form a disc -
+// matches ErrInvalidDisc with errors.Is
+type DiscError struct {
+	// Center the offending center point - may be nil
+	Center *point.Point
+	// Radius the offending radius in meters
+	Radius float64
+	// Reason why the disc was rejected
+	Reason string
+}
+
+func (e *DiscError) Error() string {
+	return fmt.Sprintf("%v: %s - incoming center/radius: [%v / %f]", ErrInvalidDisc, e.Reason, e.Center, e.Radius)
+}
+
+func (e *DiscError) Unwrap() error {
+	return ErrInvalidDisc
+}
+
+// GeometryTypeError reports a GEOS geometry type that cannot be converted -
+// matches ErrUnsupportedGeometryType with errors.Is
+type GeometryTypeError struct {
+	// Type the offending geometry type
+	Type geom.GeometryType
+	// WKT the offending geometry
+	WKT string
+}
+
+func (e *GeometryTypeError) Error() string {
+	return fmt.Sprintf("%v: %v - geometry: %s", ErrUnsupportedGeometryType, e.Type, e.WKT)
+}
+
+func (e *GeometryTypeError) Unwrap() error {
+	return ErrUnsupportedGeometryType
+}
+
+// VertexBudgetError reports an operation over its vertex budget - matches
+// ErrVertexBudget with errors.Is
+type VertexBudgetError struct {
+	// Vertices the operation would have used
+	Vertices int
+	// MaxVertices the budget from the options
+	MaxVertices int
+}
+
+func (e *VertexBudgetError) Error() string {
+	return fmt.Sprintf("%v: %d vertices over the budget of %d", ErrVertexBudget, e.Vertices, e.MaxVertices)
+}
+
+func (e *VertexBudgetError) Unwrap() error {
+	return ErrVertexBudget
+}
+
+// ProjectionError reports a point the options projection cannot map - matches
+// ErrOutsideProjection with errors.Is
+type ProjectionError struct {
+	// Center the center point of the projection
+	Center *point.Point
+	// Location the offending point
+	Location *point.Point
+	// Reason why the point cannot be mapped
+	Reason string
+}
+
+func (e *ProjectionError) Error() string {
+	return fmt.Sprintf("%v: %s - center/location: [%v %v / %v %v]",
+		ErrOutsideProjection, e.Reason, e.Center.Lat, e.Center.Lng, e.Location.Lat, e.Location.Lng)
+}
+
+func (e *ProjectionError) Unwrap() error {
+	return ErrOutsideProjection
+}
+
+// GEOSPanicError reports a recovered panic from the GEOS C library - matches
+// ErrGEOSPanic with errors.Is and unwraps to the panic value when it is an error
+type GEOSPanicError struct {
+	// Value the recovered panic value
+	Value interface{}
+	// Stack the debug stack at the time of the panic
+	Stack []byte
+}
+
+func (e *GEOSPanicError) Error() string {
+	return fmt.Sprintf("%v: %v - Debug Stack: %s", ErrGEOSPanic, e.Value, e.Stack)
+}
+
+func (e *GEOSPanicError) Is(target error) bool {
+	return target == ErrGEOSPanic
+}
+
+func (e *GEOSPanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// formatPoints returns the lat,lng values of points such as [[1 2] [3 4]] -
+// nil points are shown as <nil>
+func formatPoints(points []*point.Point) string {
+	var b strings.Builder
+	b.WriteString("[")
+	for i, p := range points {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		if p == nil {
+			b.WriteString("<nil>")
+			continue
+		}
+		fmt.Fprintf(&b, "[%v %v]", p.Lat, p.Lng)
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// recoverGEOS catches internal C library panics and reports them through err -
+// must be deferred directly
+func recoverGEOS(err *error) {
+	if e := recover(); e != nil {
+		*err = &GEOSPanicError{Value: e, Stack: debug.Stack()}
+	}
+}
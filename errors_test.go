@@ -0,0 +1,44 @@
+package gogeospace
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+func TestPolygonErrorFormatsCoordinates(t *testing.T) {
+	err := &PolygonError{
+		Coordinates: []*point.Point{{Lat: 1, Lng: 2}, nil, {Lat: -3.5, Lng: 179}},
+		Reason:      "test reason",
+		Err:         ErrInvalidPolygon,
+	}
+
+	want := "invalid polygon: test reason - incoming coordinates: [[1 2] <nil> [-3.5 179]]"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestInvalidCoordinatesAreInvalidPolygons(t *testing.T) {
+	rings := map[string]point.Ring{
+		"NaN lat":       {{Lat: math.NaN(), Lng: 0}, {Lat: 1, Lng: 0}, {Lat: 1, Lng: 1}},
+		"infinite lng":  {{Lat: 0, Lng: math.Inf(1)}, {Lat: 1, Lng: 0}, {Lat: 1, Lng: 1}},
+		"lat past pole": {{Lat: 95, Lng: 0}, {Lat: 1, Lng: 0}, {Lat: 1, Lng: 1}},
+	}
+
+	for name, ring := range rings {
+		t.Run(name, func(t *testing.T) {
+			if _, err := IntersectPolygonWithDisc(ring, &point.Point{Lat: 1, Lng: 1}, 10000, Options{}); !errors.Is(err, ErrInvalidPolygon) || errors.Is(err, ErrGEOSPanic) {
+				t.Errorf("IntersectPolygonWithDisc() error = %v, want %v", err, ErrInvalidPolygon)
+			}
+			if _, err := Union(ring, box(0, 0, 1, 1)); !errors.Is(err, ErrInvalidPolygon) || errors.Is(err, ErrGEOSPanic) {
+				t.Errorf("Union() error = %v, want %v", err, ErrInvalidPolygon)
+			}
+			if _, err := Intersects(box(0, 0, 1, 1), ring); !errors.Is(err, ErrInvalidPolygon) || errors.Is(err, ErrGEOSPanic) {
+				t.Errorf("Intersects() error = %v, want %v", err, ErrInvalidPolygon)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package gogeospace
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/projection"
+	"github.com/jdejesus007/gogeospace/utils"
+)
+
+// frame maps points to and from the plane GEOS operates in - without a
+// projection x is lat and y is lng in degrees within 180 degrees of the
+// reference lng so shapes crossing the antimeridian stay continuous,
+// otherwise x,y are meters
+type frame struct {
+	proj projection.Projection
+	lat  float64
+	lng  float64
+
+	// densify inserts points along input edges longer than this many meters
+	// with the method Earth model
+	densify float64
+	method  Method
+}
+
+// newFrame creates the plane selected by the options centered at lat, lng -
+// the passed in points must lie where the projection can map them
+func newFrame(opts Options, lat, lng float64, points ...[]*point.Point) (frame, error) {
+	f := frame{lat: lat, lng: utils.NormalizeLongitude(lng), densify: opts.DensifyMeters, method: opts.Method}
+	switch opts.Projection {
+	case ProjectionAzimuthalEquidistant:
+		f.proj = projection.NewAzimuthalEquidistant(lat, f.lng)
+	case ProjectionLambertEqualArea:
+		f.proj = projection.NewLambertAzimuthalEqualArea(lat, f.lng)
+	case ProjectionGnomonic:
+		f.proj = projection.NewGnomonic(lat, f.lng)
+	}
+	return f, f.checkDomain(points...)
+}
+
+// newFrameForPoints creates the plane selected by the options centered on the
+// bounding box of all the passed in points
+func newFrameForPoints(opts Options, points ...[]*point.Point) (frame, error) {
+	lat, lng := centerOf(points...)
+	return newFrame(opts, lat, lng, points...)
+}
+
+// checkDomain rejects points the projection of the frame cannot map - a
+// gnomonic projection only maps points less than 90 degrees from its center.
+// Nil points are left for geometry building to reject
+func (f frame) checkDomain(points ...[]*point.Point) error {
+	if _, ok := f.proj.(*projection.Gnomonic); !ok {
+		return nil
+	}
+
+	for _, ring := range points {
+		for _, p := range ring {
+			if p != nil && haversine.Distance(f.lat, f.lng, p.Lat, p.Lng) >= math.Pi/2.0*haversine.EARTH_RADIUS_CONSTANT {
+				return &ProjectionError{
+					Center:   &point.Point{Lat: f.lat, Lng: f.lng},
+					Location: p,
+					Reason:   "gnomonic projection only maps points less than 90 degrees from its center",
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// coord maps a single point to the frame plane
+func (f frame) coord(p *point.Point) geom.Coord {
+	if f.proj == nil {
+		return geom.NewCoord(p.Lat, utils.UnwrapLongitude(p.Lng, f.lng))
+	}
+
+	x, y := f.proj.Forward(p.Lat, p.Lng)
+	return geom.NewCoord(x, y)
+}
+
+// coords maps a ring or line to the frame plane - without a projection each
+// one is unwrapped around its own middle and shifted by whole turns to lie
+// around the reference lng, it may still reach past the frame lng window
+func (f frame) coords(points []*point.Point, reference float64) []geom.Coord {
+	coords := make([]geom.Coord, 0, len(points)+1)
+	if f.proj != nil {
+		for _, p := range points {
+			coords = append(coords, f.coord(p))
+		}
+		return coords
+	}
+
+	for i, lng := range unwrapLngs(points, reference) {
+		coords = append(coords, geom.NewCoord(points[i].Lat, lng))
+	}
+	return coords
+}
+
+// fits returns true if the coords lie within the lng window of the frame
+func (f frame) fits(coords []geom.Coord) bool {
+	if f.proj != nil {
+		return true
+	}
+
+	for _, c := range coords {
+		if math.Abs(c.Y-f.lng) > 180.0+ANTIMERIDIAN_TOLERANCE {
+			return false
+		}
+	}
+	return true
+}
+
+// fold cuts a geometry reaching past the lng window of the frame into the
+// pieces landing in it when shifted by whole turns - build creates the
+// geometry shifted by degrees of lng. Pieces of lower dimension left where
+// the geometry touches the window edge are dropped
+func (f frame) fold(geoType geom.GeometryType, build func(shift float64) (*geom.Geometry, error)) (*geom.Geometry, error) {
+	window, err := lngBox(f.lng-180.0, f.lng+180.0)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []*geom.Geometry
+	for _, shift := range []float64{-360.0, 0, 360.0} {
+		geo, err := build(shift)
+		if err != nil {
+			return nil, err
+		}
+
+		clipped, err := geo.Intersection(window)
+		if err != nil {
+			return nil, fmt.Errorf("failed folding into the lng window: %w", err)
+		}
+
+		parts, err = appendParts(parts, clipped, geoType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+
+	if geoType == geom.POLYGON {
+		return geom.NewCollection(geom.MULTIPOLYGON, parts...)
+	}
+	return geom.NewCollection(geom.MULTILINESTRING, parts...)
+}
+
+// densifyPoints inserts points along input edges when the options ask for it
+// - closed densifies the edge back to the first point
+func (f frame) densifyPoints(points []*point.Point, closed bool) []*point.Point {
+	if f.densify <= 0 {
+		return points
+	}
+	if closed {
+		points = openRing(points)
+	}
+	return densifyPoints(points, f.densify, f.method, closed)
+}
+
+// point maps a coord back to lat,lng - the lng may still lie past the
+// antimeridian until the result is split with splitAntimeridian
+func (f frame) point(c geom.Coord) *point.Point {
+	if f.proj == nil {
+		return &point.Point{Lat: math.Max(-90.0, math.Min(90.0, c.X)), Lng: c.Y}
+	}
+
+	lat, lng := f.proj.Inverse(c.X, c.Y)
+	return &point.Point{Lat: lat, Lng: utils.UnwrapLongitude(lng, f.lng)}
+}
+
+// unwrapLngs returns the lngs of a ring or line unwrapped edge by edge so no
+// edge jumps across the antimeridian unless it turns a whole circle along a
+// parallel - shifted by whole turns so the middle of
+// their range lies within 180 degrees of the reference lng
+func unwrapLngs(points []*point.Point, reference float64) []float64 {
+	lngs := make([]float64, len(points))
+	minLng, maxLng := math.Inf(1), math.Inf(-1)
+	for i, p := range points {
+		lngs[i] = p.Lng
+		if i > 0 {
+			lngs[i] = lngs[i-1] + lngStep(points[i-1], p)
+		}
+		minLng = math.Min(minLng, lngs[i])
+		maxLng = math.Max(maxLng, lngs[i])
+	}
+
+	middle := (minLng + maxLng) / 2.0
+	shift := utils.UnwrapLongitude(middle, reference) - middle
+	for i := range lngs {
+		lngs[i] += shift
+	}
+	return lngs
+}
+
+// middleLng returns the middle of the lng range of coords in a degree plane
+func middleLng(coords []geom.Coord) float64 {
+	minLng, maxLng := math.Inf(1), math.Inf(-1)
+	for _, c := range coords {
+		minLng = math.Min(minLng, c.Y)
+		maxLng = math.Max(maxLng, c.Y)
+	}
+	return (minLng + maxLng) / 2.0
+}
+
+// shiftCoords copies coords moved by degrees of lng in a degree plane
+func shiftCoords(coords []geom.Coord, shift float64) []geom.Coord {
+	shifted := make([]geom.Coord, len(coords))
+	for i, c := range coords {
+		shifted[i] = geom.NewCoord(c.X, c.Y+shift)
+	}
+	return shifted
+}
+
+// centerOf returns the center of the bounding box of all the passed in points
+// - the lng range is the shortest arc holding every lng so boxes crossing the
+// antimeridian are centered on it. Nil points are left for polygon building
+// to reject
+func centerOf(points ...[]*point.Point) (float64, float64) {
+	minLat, maxLat := math.Inf(1), math.Inf(-1)
+	var lngs []float64
+	for _, ring := range points {
+		for _, p := range ring {
+			if p == nil {
+				continue
+			}
+			minLat = math.Min(minLat, p.Lat)
+			maxLat = math.Max(maxLat, p.Lat)
+			lngs = append(lngs, p.Lng)
+		}
+	}
+
+	if len(lngs) == 0 {
+		return 0, 0
+	}
+
+	west, span := lngRange(lngs)
+	return (minLat + maxLat) / 2.0, utils.NormalizeLongitude(west + span/2.0)
+}
+
+// lngRange returns the west end and the span in degrees of the shortest arc
+// holding every lng - the circle minus the widest gap between neighbouring
+// lngs, the gap across the antimeridian by default
+func lngRange(lngs []float64) (float64, float64) {
+	sorted := make([]float64, len(lngs))
+	for i, lng := range lngs {
+		sorted[i] = utils.NormalizeLongitude(lng)
+	}
+	sort.Float64s(sorted)
+
+	west, span := sorted[0], sorted[len(sorted)-1]-sorted[0]
+	gap := 360.0 - span
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i]-sorted[i-1] > gap {
+			gap = sorted[i] - sorted[i-1]
+			west, span = sorted[i], 360.0-gap
+		}
+	}
+	return west, span
+}
@@ -0,0 +1,47 @@
+package gogeospace
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+func TestGnomonicDomain(t *testing.T) {
+	gnomonic := Options{Projection: ProjectionGnomonic}
+	wide := box(0, -100, 10, 100)
+
+	if _, err := Contains(wide, &point.Point{Lat: 5}, gnomonic); !errors.Is(err, ErrOutsideProjection) {
+		t.Errorf("Contains() error = %v, want %v", err, ErrOutsideProjection)
+	}
+
+	if _, err := IntersectPolygonWithDisc(wide, &point.Point{Lat: 5, Lng: 95}, 100000, gnomonic); !errors.Is(err, ErrOutsideProjection) {
+		t.Errorf("IntersectPolygonWithDisc() error = %v, want %v", err, ErrOutsideProjection)
+	}
+
+	// Centered on lng 0 the points at lng ±100 lie past 90 degrees
+	triangle := point.Ring{{Lng: -100}, {Lat: 10}, {Lng: 100}}
+	if _, err := PointOnSurface(triangle, gnomonic); !errors.Is(err, ErrOutsideProjection) {
+		t.Errorf("PointOnSurface() error = %v, want %v", err, ErrOutsideProjection)
+	}
+
+	prepared, err := NewPreparedPolygon(box(0, 0, 10, 10), gnomonic)
+	if err != nil {
+		t.Fatalf("NewPreparedPolygon() error = %v", err)
+	}
+	defer prepared.Close()
+
+	if _, err := prepared.ContainsPoint(&point.Point{Lat: 5, Lng: 120}); !errors.Is(err, ErrOutsideProjection) {
+		t.Errorf("ContainsPoint() error = %v, want %v", err, ErrOutsideProjection)
+	}
+
+	contains, err := prepared.ContainsPoint(&point.Point{Lat: 5, Lng: 5})
+	if err != nil || !contains {
+		t.Errorf("ContainsPoint() = %v, %v, want true", contains, err)
+	}
+
+	collection, err := IntersectPolygonWithDisc(box(0, 0, 10, 10), &point.Point{Lat: 5, Lng: 5}, 100000, gnomonic)
+	if err != nil || len(collection.Polygons) != 1 {
+		t.Errorf("IntersectPolygonWithDisc() = %v, %v, want 1 polygon", collection, err)
+	}
+}
@@ -0,0 +1,317 @@
+package gogeospace
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/utils"
+	"github.com/jdejesus007/gogeospace/vincenty"
+)
+
+// geometryOp is a GEOS operation deriving one geometry from another
+type geometryOp func(g *geom.Geometry) (*geom.Geometry, error)
+
+// Bounds is a lat,lng bounding box in degrees - MinLng is greater than MaxLng
+// when the box crosses the antimeridian
+type Bounds struct {
+	MinLat float64 `json:"minLat"`
+	MinLng float64 `json:"minLng"`
+	MaxLat float64 `json:"maxLat"`
+	MaxLng float64 `json:"maxLng"`
+}
+
+// Centroid returns the area-weighted center of polygons - computed in a
+// Lambert azimuthal equal-area projection of the authalic latitudes so areas
+// are weighted as on the WGS-84 ellipsoid, or on the sphere with the
+// haversine method. The centroid of a concave shape may lie outside it
+// Params:
+// Polygonal rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the Earth model areas are weighted on
+func Centroid(polygonal point.Polygonal, opts ...Options) (centroid *point.Point, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	options := getOptions(opts)
+	polygons, err := polygonsOf(polygonal, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Equal areas on the authalic sphere are equal areas on the ellipsoid
+	ellipsoidal := options.Method == MethodVincenty
+	if ellipsoidal {
+		polygons = mapPolygons(polygons, func(p *point.Point) *point.Point {
+			return &point.Point{Lat: vincenty.AuthalicLatitude(p.Lat), Lng: p.Lng}
+		})
+	}
+
+	options.Projection = ProjectionLambertEqualArea
+	centroid, err = geographicPoint("centroid", (*geom.Geometry).Centroid, polygons, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if ellipsoidal {
+		centroid.Lat = vincenty.GeodeticLatitude(centroid.Lat)
+	}
+
+	return centroid, nil
+}
+
+// PointOnSurface returns a point guaranteed to lie inside polygons - such as a
+// label position. Computed in a gnomonic projection so edges follow great
+// circles
+// Params:
+// Polygonal rings, polygons or multi polygons of lat,lng in degrees within
+// 90 degrees of their center
+// Opts optional settings such as repair
+func PointOnSurface(polygonal point.Polygonal, opts ...Options) (surfacePoint *point.Point, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	options := getOptions(opts)
+	polygons, err := polygonsOf(polygonal, options)
+	if err != nil {
+		return nil, err
+	}
+
+	options.Projection = ProjectionGnomonic
+	return geographicPoint("point on surface", (*geom.Geometry).PointOnSurface, polygons, options)
+}
+
+// ConvexHull returns the smallest convex polygon holding polygons with edges
+// along great circles - computed in a gnomonic projection where great circles
+// are straight. Split in two only where it crosses the antimeridian
+// Params:
+// Polygonal rings, polygons or multi polygons of lat,lng in degrees within
+// 90 degrees of their center
+// Opts optional settings such as repair
+func ConvexHull(polygonal point.Polygonal, opts ...Options) (multiPolygon point.MultiPolygon, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	options := getOptions(opts)
+	polygons, err := polygonsOf(polygonal, options)
+	if err != nil {
+		return nil, err
+	}
+
+	options.Projection = ProjectionGnomonic
+	hull, f, err := geographicGeometry("convex hull", (*geom.Geometry).ConvexHull, polygons, options)
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := collectionFromGeos(hull, f, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.Polygons, nil
+}
+
+// BoundingBox returns the smallest lat,lng box holding polygons - edges are
+// great circles so the box reaches past vertices where edges bulge toward a
+// pole unless they run along a parallel, boxes crossing the antimeridian have MinLng greater than MaxLng and
+// polygons around a pole or the whole globe span every lng
+// Params:
+// Polygonal rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as repair
+func BoundingBox(polygonal point.Polygonal, opts ...Options) (bounds *Bounds, err error) {
+	// Catch internal C library panics of repair
+	defer recoverGEOS(&err)
+
+	polygons, err := polygonsOf(polygonal, getOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds = &Bounds{MinLat: math.Inf(1), MaxLat: math.Inf(-1)}
+	var lngs []float64
+	everyLng := false
+	for _, polygon := range polygons {
+		// Holes lie within their exterior
+		ring := polygon.Exterior
+		everyLng = everyLng || fullWidth(ring)
+		if raw, pole, ok := poleRing(ring); ok {
+			everyLng = true
+			bounds.MinLat = math.Min(bounds.MinLat, pole)
+			bounds.MaxLat = math.Max(bounds.MaxLat, pole)
+			ring = raw
+		}
+
+		ring = openRing(ring)
+		for i, p := range ring {
+			minLat, maxLat := edgeLatRange(p, ring[(i+1)%len(ring)])
+			bounds.MinLat = math.Min(bounds.MinLat, minLat)
+			bounds.MaxLat = math.Max(bounds.MaxLat, maxLat)
+			lngs = append(lngs, p.Lng)
+		}
+	}
+
+	if len(lngs) == 0 {
+		return nil, &PolygonError{Reason: "no polygons", Err: ErrTooFewPoints}
+	}
+
+	if everyLng {
+		bounds.MinLng, bounds.MaxLng = -180.0, 180.0
+		return bounds, nil
+	}
+
+	west, span := lngRange(lngs)
+	bounds.MinLng = west
+	bounds.MaxLng = utils.NormalizeLongitude(west + span)
+	return bounds, nil
+}
+
+// geographicPoint runs a GEOS operation returning a single point in the
+// options projection centered on the polygons
+func geographicPoint(name string, op geometryOp, polygons point.MultiPolygon, opts Options) (*point.Point, error) {
+	geo, f, err := geographicGeometry(name, op, polygons, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := collectionFromGeos(geo, f, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(collection.Points) != 1 {
+		return nil, &PolygonError{Reason: fmt.Sprintf("%s of %d points", name, len(collection.Points)), Err: ErrInvalidPolygon}
+	}
+
+	return collection.Points[0], nil
+}
+
+// geographicGeometry runs a GEOS operation in the options projection centered
+// on the polygons
+func geographicGeometry(name string, op geometryOp, polygons point.MultiPolygon, opts Options) (*geom.Geometry, frame, error) {
+	var points [][]*point.Point
+	for _, polygon := range polygons {
+		points = append(points, polygon.Exterior)
+	}
+
+	f, err := newFrameForPoints(opts, points...)
+	if err != nil {
+		return nil, f, err
+	}
+
+	geo, err := getGeosGeometryFromMultiPolygon(polygons, f)
+	if err != nil {
+		return nil, f, err
+	}
+
+	result, err := op(geo)
+	if err != nil {
+		return nil, f, fmt.Errorf("failed %s: %w", name, err)
+	}
+
+	return result, f, nil
+}
+
+// polygonsOf returns the polygons of the polygonal input - every ring is
+// repaired first when the options ask for it
+func polygonsOf(polygonal point.Polygonal, opts Options) (point.MultiPolygon, error) {
+	if polygonal == nil {
+		return nil, &PolygonError{Reason: "nil polygon", Err: ErrInvalidPolygon}
+	}
+
+	if opts.Repair {
+		return repairPolygonal(polygonal)
+	}
+
+	polygons := polygonal.Polygons()
+	for _, polygon := range polygons {
+		if polygon == nil {
+			return nil, &PolygonError{Reason: "nil polygon", Err: ErrInvalidPolygon}
+		}
+		for _, ring := range append([][]*point.Point{polygon.Exterior}, polygon.Interiors...) {
+			for _, p := range ring {
+				if p == nil {
+					return nil, &PolygonError{Coordinates: ring, Reason: "nil point", Err: ErrInvalidPolygon}
+				}
+			}
+		}
+	}
+
+	return polygons, nil
+}
+
+// mapPolygons copies polygons with every point mapped by fn
+func mapPolygons(polygons point.MultiPolygon, fn func(p *point.Point) *point.Point) point.MultiPolygon {
+	return mapRings(polygons, func(ring []*point.Point) []*point.Point {
+		mapped := make([]*point.Point, len(ring))
+		for i, p := range ring {
+			mapped[i] = fn(p)
+		}
+		return mapped
+	})
+}
+
+// mapRings copies polygons with every exterior and hole ring mapped by fn
+func mapRings(polygons point.MultiPolygon, fn func(ring []*point.Point) []*point.Point) point.MultiPolygon {
+	mapped := make(point.MultiPolygon, len(polygons))
+	for i, polygon := range polygons {
+		mapped[i] = &point.Polygon{Exterior: fn(polygon.Exterior)}
+		for _, interior := range polygon.Interiors {
+			mapped[i].Interiors = append(mapped[i].Interiors, fn(interior))
+		}
+	}
+	return mapped
+}
+
+// edgeLatRange returns the lowest and highest lat in degrees along the great
+// circle edge from a to b - edges bulge toward the pole between vertices
+// unless they run along a parallel
+func edgeLatRange(a, b *point.Point) (float64, float64) {
+	minLat, maxLat := math.Min(a.Lat, b.Lat), math.Max(a.Lat, b.Lat)
+	if a.Lat == b.Lat {
+		return minLat, maxLat
+	}
+
+	va, vb := unitVector(a), unitVector(b)
+	n := cross(va, vb)
+	nNorm := math.Sqrt(dot(n, n))
+	if nNorm == 0 {
+		return minLat, maxLat
+	}
+	n = [3]float64{n[0] / nNorm, n[1] / nNorm, n[2] / nNorm}
+
+	// Northernmost point of the great circle - the southernmost is opposite
+	top := [3]float64{-n[0] * n[2], -n[1] * n[2], 1.0 - n[2]*n[2]}
+	topNorm := math.Sqrt(dot(top, top))
+	if topNorm == 0 {
+		return minLat, maxLat
+	}
+	top = [3]float64{top[0] / topNorm, top[1] / topNorm, top[2] / topNorm}
+	topLat := utils.RadToDegrees(math.Asin(math.Max(-1.0, math.Min(1.0, top[2]))))
+
+	onArc := func(v [3]float64) bool {
+		return dot(cross(va, v), n) > 0 && dot(cross(v, vb), n) > 0
+	}
+	if onArc(top) {
+		maxLat = math.Max(maxLat, topLat)
+	}
+	if onArc([3]float64{-top[0], -top[1], -top[2]}) {
+		minLat = math.Min(minLat, -topLat)
+	}
+
+	return minLat, maxLat
+}
+
+func unitVector(p *point.Point) [3]float64 {
+	latRad := utils.DegreesToRadians(p.Lat)
+	lngRad := utils.DegreesToRadians(p.Lng)
+	return [3]float64{math.Cos(latRad) * math.Cos(lngRad), math.Cos(latRad) * math.Sin(lngRad), math.Sin(latRad)}
+}
+
+func cross(u, v [3]float64) [3]float64 {
+	return [3]float64{u[1]*v[2] - u[2]*v[1], u[2]*v[0] - u[0]*v[2], u[0]*v[1] - u[1]*v[0]}
+}
+
+func dot(u, v [3]float64) float64 {
+	return u[0]*v[0] + u[1]*v[1] + u[2]*v[2]
+}
@@ -0,0 +1,132 @@
+package gogeospace
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/utils"
+)
+
+// lShape is the unit squares at lat,lng (0,0), (0,1) and (1,0)
+var lShape = point.Ring{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 2}, {Lat: 1, Lng: 2}, {Lat: 1, Lng: 1}, {Lat: 2, Lng: 1}, {Lat: 2, Lng: 0}}
+
+func TestCentroid(t *testing.T) {
+	tests := []struct {
+		name      string
+		polygonal point.Polygonal
+		method    Method
+		centroid  point.Point
+	}{
+		{name: "box", polygonal: box(-1, 10, 1, 14), method: MethodHaversine, centroid: point.Point{Lat: 0, Lng: 12}},
+		{name: "box on the ellipsoid", polygonal: box(-1, 10, 1, 14), method: MethodVincenty, centroid: point.Point{Lat: 0, Lng: 12}},
+		{name: "mirrored boxes", polygonal: point.MultiPolygon{{Exterior: box(-3, -3, -1, -1)}, {Exterior: box(1, 1, 3, 3)}},
+			method: MethodHaversine, centroid: point.Point{Lat: 0, Lng: 0}},
+		{name: "box with centered hole", polygonal: &point.Polygon{Exterior: box(-2, -2, 2, 2), Interiors: [][]*point.Point{box(-1, -1, 1, 1)}},
+			method: MethodHaversine, centroid: point.Point{Lat: 0, Lng: 0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			centroid, err := Centroid(test.polygonal, Options{Method: test.method})
+			if err != nil {
+				t.Fatalf("Centroid() error = %v", err)
+			}
+			if math.Abs(centroid.Lat-test.centroid.Lat) > 1e-9 || math.Abs(centroid.Lng-test.centroid.Lng) > 1e-9 {
+				t.Errorf("Centroid() = %+v, want %+v", *centroid, test.centroid)
+			}
+		})
+	}
+
+	// Northern boxes weigh their wider southern half
+	centroid, err := Centroid(box(60, 0, 70, 10))
+	if err != nil || !(centroid.Lat < 65) {
+		t.Errorf("Centroid() = %+v, %v, want south of lat 65", centroid, err)
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	// The hull cuts the notch of the L with one great circle edge
+	want := areaOf(t, point.Ring{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 2}, {Lat: 1, Lng: 2}, {Lat: 2, Lng: 1}, {Lat: 2, Lng: 0}})
+
+	hull, err := ConvexHull(lShape)
+	if err != nil {
+		t.Fatalf("ConvexHull() error = %v", err)
+	}
+	if len(hull) != 1 || len(hull[0].Interiors) != 0 {
+		t.Fatalf("ConvexHull() = %d polygons, want 1 without holes", len(hull))
+	}
+	if vertices := len(openRing(hull[0].Exterior)); vertices != 5 {
+		t.Errorf("ConvexHull() = %d vertices, want 5", vertices)
+	}
+	if area := areaOf(t, hull); math.Abs(area-want) > 1e-6*want {
+		t.Errorf("ConvexHull() area = %v, want %v", area, want)
+	}
+
+	// A convex polygon is its own hull
+	hull, err = ConvexHull(box(0, 0, 1, 1))
+	if want := areaOf(t, box(0, 0, 1, 1)); err != nil || math.Abs(areaOf(t, hull)-want) > 1e-6*want {
+		t.Errorf("ConvexHull(box) = %v, %v, want area %v", hull, err, want)
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	// The great circle with its top at lat 45 over lng 0 passes lat
+	// atan(cos(lng)) at every other lng
+	onCircle := func(lng float64) *point.Point {
+		return &point.Point{Lat: utils.RadToDegrees(math.Atan(math.Cos(utils.DegreesToRadians(lng)))), Lng: lng}
+	}
+
+	tests := []struct {
+		name   string
+		ring   point.Ring
+		bounds Bounds
+	}{
+		{name: "box", ring: box(-1, 10, 1, 14), bounds: Bounds{MinLat: -1, MinLng: 10, MaxLat: 1, MaxLng: 14}},
+		{name: "L shape", ring: lShape, bounds: Bounds{MinLat: 0, MinLng: 0, MaxLat: 2, MaxLng: 2}},
+		{name: "edge bulging north", ring: point.Ring{onCircle(-60), onCircle(30), {Lat: 10, Lng: -15}},
+			bounds: Bounds{MinLat: 10, MinLng: -60, MaxLat: 45, MaxLng: 30}},
+		{name: "across the antimeridian", ring: box(0, 179, 1, -179), bounds: Bounds{MinLat: 0, MinLng: 179, MaxLat: 1, MaxLng: -179}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bounds, err := BoundingBox(test.ring)
+			if err != nil {
+				t.Fatalf("BoundingBox() error = %v", err)
+			}
+			if math.Abs(bounds.MinLat-test.bounds.MinLat) > 1e-9 || math.Abs(bounds.MinLng-test.bounds.MinLng) > 1e-9 ||
+				math.Abs(bounds.MaxLat-test.bounds.MaxLat) > 1e-9 || math.Abs(bounds.MaxLng-test.bounds.MaxLng) > 1e-9 {
+				t.Errorf("BoundingBox() = %+v, want %+v", *bounds, test.bounds)
+			}
+		})
+	}
+}
+
+func TestPointOnSurface(t *testing.T) {
+	// The centroid of the holed box lies in its hole but the surface point does
+	// not
+	tests := []struct {
+		name      string
+		polygonal point.Polygonal
+	}{
+		{name: "L shape", polygonal: lShape},
+		{name: "box with hole", polygonal: &point.Polygon{Exterior: box(0, 0, 3, 3), Interiors: [][]*point.Point{box(0.5, 0.5, 2.5, 2.5)}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			surfacePoint, err := PointOnSurface(test.polygonal)
+			if err != nil {
+				t.Fatalf("PointOnSurface() error = %v", err)
+			}
+			geometry, ok := test.polygonal.(point.Geometry)
+			if !ok {
+				t.Fatalf("%T is not a geometry", test.polygonal)
+			}
+			if contains, err := Contains(geometry, surfacePoint); err != nil || !contains {
+				t.Errorf("Contains(%+v) = %v, %v, want true", *surfacePoint, contains, err)
+			}
+		})
+	}
+}
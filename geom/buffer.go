@@ -0,0 +1,248 @@
+//go:build purego
+// +build purego
+
+package geom
+
+import (
+	"math"
+)
+
+// Buffer returns the area within the distance of the geometry - round caps
+// and joins with 8 segments per quadrant
+func (g *Geometry) Buffer(d float64) (*Geometry, error) {
+	return g.BufferWithOpts(d, BufferOpts{QuadSegs: 8, CapStyle: CapRound, JoinStyle: JoinRound, MitreLimit: 5})
+}
+
+// BufferWithOpts returns the area within the width of the geometry - grown
+// for positive widths and shrunk for negative widths of areas. Built as the
+// union of a rectangle per segment with the caps and joins between them -
+// rings only take the half rectangles and joins on the side the width moves
+// their edges to. Mitres longer than the mitre limit are beveled
+func (g *Geometry) BufferWithOpts(width float64, opts BufferOpts) (*Geometry, error) {
+	c := g.components()
+	if opts.QuadSegs < 1 {
+		opts.QuadSegs = 1
+	}
+
+	// A zero width rebuilds the areas valid and drops lines and points
+	if width == 0 || math.IsNaN(width) || (width < 0 && len(c.rings) == 0) {
+		areal := &components{rings: c.rings}
+		arr := newArrangement(areal, &components{})
+		return polygonal(arr.polygons(arr.areaEdges(unionRule))), nil
+	}
+
+	b := &bufferer{r: math.Abs(width), opts: opts, pieces: &components{}}
+	// Areas lie left of their rings so growing moves the edges right
+	side := 1
+	if width < 0 {
+		side = -1
+	}
+	for _, ring := range c.rings {
+		b.path(ring, true, side)
+	}
+	if width > 0 {
+		for _, line := range c.lines {
+			b.path(line, false, 0)
+		}
+		for _, p := range c.points {
+			b.cap(p, Coord{X: 1}, true)
+		}
+	}
+
+	areal := &components{rings: c.rings}
+	arr := newArrangement(areal, b.pieces)
+	if width > 0 {
+		return polygonal(arr.polygons(arr.areaEdges(unionRule))), nil
+	}
+	return polygonal(arr.polygons(arr.areaEdges(differenceRule))), nil
+}
+
+// bufferer collects the counterclockwise pieces whose union is the buffer of
+// the linework
+type bufferer struct {
+	r      float64
+	opts   BufferOpts
+	pieces *components
+}
+
+func (b *bufferer) piece(ring ...Coord) {
+	ring = append(ring, ring[0])
+	if signedArea(ring) < 0 {
+		ring = reversed(ring)
+	}
+	b.pieces.rings = append(b.pieces.rings, ring)
+}
+
+// path adds the segments and joins of a line or closed ring and the caps of a
+// line - on the right side only for a positive side, the left for a negative
+// side and both for zero
+func (b *bufferer) path(coords []Coord, closed bool, side int) {
+	var points []Coord
+	for _, c := range coords {
+		if len(points) == 0 || !samePoint(points[len(points)-1], c) {
+			points = append(points, c)
+		}
+	}
+	if closed && len(points) > 1 && samePoint(points[0], points[len(points)-1]) {
+		points = points[:len(points)-1]
+	}
+
+	switch {
+	case len(points) == 0:
+		return
+	case len(points) == 1:
+		if !closed {
+			b.cap(points[0], Coord{X: 1}, true)
+		}
+		return
+	}
+
+	n := len(points)
+	segments := n - 1
+	if closed {
+		segments = n
+	}
+	for i := 0; i < segments; i++ {
+		p, q := points[i], points[(i+1)%n]
+		normal := b.normal(p, q)
+		switch {
+		case side > 0:
+			b.piece(Coord{X: p.X - normal.X, Y: p.Y - normal.Y}, Coord{X: q.X - normal.X, Y: q.Y - normal.Y}, q, p)
+		case side < 0:
+			b.piece(p, q, Coord{X: q.X + normal.X, Y: q.Y + normal.Y}, Coord{X: p.X + normal.X, Y: p.Y + normal.Y})
+		default:
+			b.piece(
+				Coord{X: p.X - normal.X, Y: p.Y - normal.Y},
+				Coord{X: q.X - normal.X, Y: q.Y - normal.Y},
+				Coord{X: q.X + normal.X, Y: q.Y + normal.Y},
+				Coord{X: p.X + normal.X, Y: p.Y + normal.Y},
+			)
+		}
+	}
+
+	for i := 0; i < n; i++ {
+		if !closed && (i == 0 || i == n-1) {
+			continue
+		}
+		b.join(points[(i+n-1)%n], points[i], points[(i+1)%n], side)
+	}
+
+	if !closed {
+		b.cap(points[0], direction(points[1], points[0]), false)
+		b.cap(points[n-1], direction(points[n-2], points[n-1]), false)
+	}
+}
+
+// normal is the left normal of the segment scaled to the width
+func (b *bufferer) normal(p, q Coord) Coord {
+	d := direction(p, q)
+	return Coord{X: -d.Y * b.r, Y: d.X * b.r}
+}
+
+// join fills the gap on the outside of the turn at v - a fan of the arc for
+// round joins. Gaps away from a non-zero side are left open
+func (b *bufferer) join(u, v, w Coord, side int) {
+	turn := orientation(u, v, w)
+	if turn == 0 {
+		// Doubling back opens the whole end around v
+		d1, d2 := direction(u, v), direction(v, w)
+		if b.opts.JoinStyle != JoinMitre && b.opts.JoinStyle != JoinBevel && d1.X*d2.X+d1.Y*d2.Y < 0 {
+			b.disc(v)
+		}
+		return
+	}
+
+	// A left turn opens the gap on the right
+	if side != 0 && (turn > 0) != (side > 0) {
+		return
+	}
+	n1, n2 := b.normal(u, v), b.normal(v, w)
+	if turn > 0 {
+		n1, n2 = Coord{X: -n1.X, Y: -n1.Y}, Coord{X: -n2.X, Y: -n2.Y}
+	}
+	p1 := Coord{X: v.X + n1.X, Y: v.Y + n1.Y}
+	p2 := Coord{X: v.X + n2.X, Y: v.Y + n2.Y}
+
+	switch b.opts.JoinStyle {
+	case JoinMitre, JoinBevel:
+	default:
+		b.piece(append([]Coord{v}, b.arc(v, n1, n2)...)...)
+		return
+	}
+
+	sum := Coord{X: n1.X + n2.X, Y: n1.Y + n2.Y}
+	length := math.Hypot(sum.X, sum.Y)
+	if b.opts.JoinStyle == JoinMitre && length > 0 && 2*b.r/length <= b.opts.MitreLimit {
+		scale := 2 * b.r * b.r / (length * length)
+		mitre := Coord{X: v.X + sum.X*scale, Y: v.Y + sum.Y*scale}
+		b.piece(v, p1, mitre, p2)
+		return
+	}
+
+	b.piece(v, p1, p2)
+}
+
+// arc is the shorter way around c from the offset n1 to the offset n2 in steps
+// of at most a quadrant over QuadSegs
+func (b *bufferer) arc(c, n1, n2 Coord) []Coord {
+	start := math.Atan2(n1.Y, n1.X)
+	sweep := math.Atan2(n2.Y, n2.X) - start
+	if sweep > math.Pi {
+		sweep -= 2 * math.Pi
+	} else if sweep < -math.Pi {
+		sweep += 2 * math.Pi
+	}
+
+	steps := int(math.Ceil(math.Abs(sweep) / (math.Pi / 2 / float64(b.opts.QuadSegs))))
+	if steps < 1 {
+		steps = 1
+	}
+	arc := make([]Coord, 0, steps+1)
+	arc = append(arc, Coord{X: c.X + n1.X, Y: c.Y + n1.Y})
+	for i := 1; i < steps; i++ {
+		angle := start + sweep*float64(i)/float64(steps)
+		arc = append(arc, Coord{X: c.X + b.r*math.Cos(angle), Y: c.Y + b.r*math.Sin(angle)})
+	}
+	return append(arc, Coord{X: c.X + n2.X, Y: c.Y + n2.Y})
+}
+
+// cap ends a line at p heading along the unit direction - a lone point is
+// capped all around
+func (b *bufferer) cap(p, d Coord, lone bool) {
+	switch b.opts.CapStyle {
+	case CapFlat:
+		return
+	case CapSquare:
+		t := Coord{X: d.X * b.r, Y: d.Y * b.r}
+		n := Coord{X: -t.Y, Y: t.X}
+		back := 0.0
+		if lone {
+			back = 1.0
+		}
+		b.piece(
+			Coord{X: p.X - back*t.X - n.X, Y: p.Y - back*t.Y - n.Y},
+			Coord{X: p.X + t.X - n.X, Y: p.Y + t.Y - n.Y},
+			Coord{X: p.X + t.X + n.X, Y: p.Y + t.Y + n.Y},
+			Coord{X: p.X - back*t.X + n.X, Y: p.Y - back*t.Y + n.Y},
+		)
+	default:
+		b.disc(p)
+	}
+}
+
+// disc adds a circle of the width around c with QuadSegs segments a quadrant
+func (b *bufferer) disc(c Coord) {
+	segments := 4 * b.opts.QuadSegs
+	ring := make([]Coord, segments)
+	for i := range ring {
+		angle := 2 * math.Pi * float64(i) / float64(segments)
+		ring[i] = Coord{X: c.X + b.r*math.Cos(angle), Y: c.Y + b.r*math.Sin(angle)}
+	}
+	b.piece(ring...)
+}
+
+// direction is the unit vector from p to q
+func direction(p, q Coord) Coord {
+	length := math.Hypot(q.X-p.X, q.Y-p.Y)
+	return Coord{X: (q.X - p.X) / length, Y: (q.Y - p.Y) / length}
+}
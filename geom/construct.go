@@ -0,0 +1,221 @@
+//go:build purego
+// +build purego
+
+package geom
+
+import (
+	"math"
+	"sort"
+)
+
+// Centroid returns the center of mass of the highest dimension parts - area
+// weighted for areas, length weighted for lines
+func (g *Geometry) Centroid() (*Geometry, error) {
+	c, ok := g.components().centroid()
+	if !ok {
+		return &Geometry{typ: POINT}, nil
+	}
+	return &Geometry{typ: POINT, coords: []Coord{c}}, nil
+}
+
+func (c *components) centroid() (Coord, bool) {
+	if len(c.rings) > 0 {
+		// Relative to the first point to keep precision far from the origin
+		origin := c.rings[0][0]
+		var area, x, y float64
+		for _, ring := range c.rings {
+			for i := 0; i+1 < len(ring); i++ {
+				ax, ay := ring[i].X-origin.X, ring[i].Y-origin.Y
+				bx, by := ring[i+1].X-origin.X, ring[i+1].Y-origin.Y
+				cross := ax*by - bx*ay
+				area += cross
+				x += (ax + bx) * cross
+				y += (ay + by) * cross
+			}
+		}
+		if area != 0 {
+			return Coord{X: origin.X + x/(3*area), Y: origin.Y + y/(3*area)}, true
+		}
+	}
+
+	// Collapsed areas fall back to their rings as lines
+	lines := append(append([][]Coord(nil), c.rings...), c.lines...)
+	var length, x, y float64
+	for _, line := range lines {
+		for i := 0; i+1 < len(line); i++ {
+			d := math.Hypot(line[i+1].X-line[i].X, line[i+1].Y-line[i].Y)
+			length += d
+			x += (line[i].X + line[i+1].X) / 2 * d
+			y += (line[i].Y + line[i+1].Y) / 2 * d
+		}
+	}
+	if length > 0 {
+		return Coord{X: x / length, Y: y / length}, true
+	}
+
+	points := append([]Coord(nil), c.points...)
+	for _, line := range lines {
+		points = append(points, line...)
+	}
+	if len(points) == 0 {
+		return Coord{}, false
+	}
+	x, y = 0, 0
+	for _, p := range points {
+		x += p.X
+		y += p.Y
+	}
+	return Coord{X: x / float64(len(points)), Y: y / float64(len(points))}, true
+}
+
+// PointOnSurface returns a point guaranteed to lie on the geometry - the
+// middle of the widest span of a scan line through the areas, or the vertex
+// nearest the centroid for lines and points
+func (g *Geometry) PointOnSurface() (*Geometry, error) {
+	c := g.components()
+	if len(c.rings) > 0 {
+		if p, ok := interiorPointArea(g); ok {
+			return &Geometry{typ: POINT, coords: []Coord{p}}, nil
+		}
+	}
+
+	center, ok := c.centroid()
+	if !ok {
+		return &Geometry{typ: POINT}, nil
+	}
+
+	// Line interiors before line ends and points
+	var candidates []Coord
+	for _, line := range c.lines {
+		if len(line) > 2 {
+			candidates = append(candidates, line[1:len(line)-1]...)
+		}
+	}
+	if len(candidates) == 0 {
+		for _, line := range c.lines {
+			candidates = append(candidates, line[0], line[len(line)-1])
+		}
+		candidates = append(candidates, c.points...)
+	}
+
+	best, nearest := Coord{}, math.Inf(1)
+	for _, p := range candidates {
+		if d := math.Hypot(p.X-center.X, p.Y-center.Y); d < nearest {
+			best, nearest = p, d
+		}
+	}
+	return &Geometry{typ: POINT, coords: []Coord{best}}, nil
+}
+
+// interiorPointArea scans each polygon along the row between the vertices
+// nearest its middle and keeps the middle of the widest span inside
+func interiorPointArea(g *Geometry) (Coord, bool) {
+	var polygons []*Geometry
+	var walk func(g *Geometry)
+	walk = func(g *Geometry) {
+		switch {
+		case g.typ == POLYGON && !g.empty():
+			polygons = append(polygons, g)
+		case g.collection():
+			for _, part := range g.parts {
+				walk(part)
+			}
+		}
+	}
+	walk(g)
+
+	best, widest, found := Coord{}, -1.0, false
+	for _, polygon := range polygons {
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, c := range polygon.rings[0].coords {
+			lo, hi = math.Min(lo, c.Y), math.Max(hi, c.Y)
+		}
+		middle := (lo + hi) / 2
+		for _, ring := range polygon.rings {
+			for _, c := range ring.coords {
+				if c.Y <= middle && c.Y > lo {
+					lo = c.Y
+				} else if c.Y > middle && c.Y < hi {
+					hi = c.Y
+				}
+			}
+		}
+		y := (lo + hi) / 2
+
+		var xs []float64
+		for _, ring := range polygon.rings {
+			for i := 0; i+1 < len(ring.coords); i++ {
+				a, b := ring.coords[i], ring.coords[i+1]
+				if (a.Y > y) != (b.Y > y) {
+					xs = append(xs, a.X+(y-a.Y)*(b.X-a.X)/(b.Y-a.Y))
+				}
+			}
+		}
+		sort.Float64s(xs)
+
+		for i := 0; i+1 < len(xs); i += 2 {
+			if width := xs[i+1] - xs[i]; width > widest {
+				best, widest, found = Coord{X: (xs[i] + xs[i+1]) / 2, Y: y}, width, true
+			}
+		}
+	}
+	return best, found
+}
+
+// ConvexHull returns the smallest convex polygon around the geometry - a line
+// or point when the coordinates are collinear or the same
+func (g *Geometry) ConvexHull() (*Geometry, error) {
+	c := g.components()
+	var points []Coord
+	for _, ring := range c.rings {
+		points = append(points, ring...)
+	}
+	for _, line := range c.lines {
+		points = append(points, line...)
+	}
+	points = append(points, c.points...)
+
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].X != points[j].X {
+			return points[i].X < points[j].X
+		}
+		return points[i].Y < points[j].Y
+	})
+	var unique []Coord
+	for _, p := range points {
+		p.Z = 0
+		if len(unique) == 0 || !samePoint(unique[len(unique)-1], p) {
+			unique = append(unique, p)
+		}
+	}
+
+	switch len(unique) {
+	case 0:
+		return &Geometry{typ: POLYGON}, nil
+	case 1:
+		return &Geometry{typ: POINT, coords: unique}, nil
+	}
+
+	// Monotone chain - lower then upper hull counterclockwise
+	hull := make([]Coord, 0, 2*len(unique))
+	for pass := 0; pass < 2; pass++ {
+		start := len(hull)
+		for i := range unique {
+			p := unique[i]
+			if pass == 1 {
+				p = unique[len(unique)-1-i]
+			}
+			for len(hull) >= start+2 && orientation(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+				hull = hull[:len(hull)-1]
+			}
+			hull = append(hull, p)
+		}
+		hull = hull[:len(hull)-1]
+	}
+
+	if len(hull) < 3 {
+		return &Geometry{typ: LINESTRING, coords: []Coord{unique[0], unique[len(unique)-1]}}, nil
+	}
+	hull = append(hull, hull[0])
+	return polygonal([][][]Coord{{reversed(hull)}}), nil
+}
@@ -0,0 +1,5 @@
+// Package geom is the planar geometry engine behind gogeospace - the cgo GEOS
+// binding by default or a pure Go implementation of the same API when built
+// with the purego tag, for static builds, cross compilation and containers
+// without libgeos
+package geom
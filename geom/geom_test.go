@@ -0,0 +1,378 @@
+package geom_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/geom"
+)
+
+// TOLERANCE absolute difference allowed between areas of the fixtures - both
+// the GEOS and the pure Go engine must land within it
+const TOLERANCE = 1e-6
+
+// square returns the coords of the closed axis aligned square with its lower
+// corner at x, y
+func square(x, y, size float64) []geom.Coord {
+	return []geom.Coord{
+		geom.NewCoord(x, y),
+		geom.NewCoord(x+size, y),
+		geom.NewCoord(x+size, y+size),
+		geom.NewCoord(x, y+size),
+		geom.NewCoord(x, y),
+	}
+}
+
+func polygon(t *testing.T, shell []geom.Coord, holes ...[]geom.Coord) *geom.Geometry {
+	t.Helper()
+	g, err := geom.NewPolygon(shell, holes...)
+	if err != nil {
+		t.Fatalf("NewPolygon() error = %v", err)
+	}
+	return g
+}
+
+func pointAt(t *testing.T, x, y float64) *geom.Geometry {
+	t.Helper()
+	g, err := geom.NewPoint(geom.NewCoord(x, y))
+	if err != nil {
+		t.Fatalf("NewPoint() error = %v", err)
+	}
+	return g
+}
+
+// area returns the planar area of every polygon of a geometry - holes are
+// subtracted
+func area(t *testing.T, g *geom.Geometry) float64 {
+	t.Helper()
+	geoType, err := g.Type()
+	if err != nil {
+		t.Fatalf("Type() error = %v", err)
+	}
+
+	switch geoType {
+	case geom.POLYGON:
+		empty, err := g.IsEmpty()
+		if err != nil || empty {
+			return 0
+		}
+		shell, err := g.Shell()
+		if err != nil {
+			t.Fatalf("Shell() error = %v", err)
+		}
+		total := ringArea(t, shell)
+		holes, err := g.Holes()
+		if err != nil {
+			t.Fatalf("Holes() error = %v", err)
+		}
+		for _, hole := range holes {
+			total -= ringArea(t, hole)
+		}
+		return total
+	case geom.MULTIPOLYGON, geom.GEOMETRYCOLLECTION:
+		n, err := g.NGeometry()
+		if err != nil {
+			t.Fatalf("NGeometry() error = %v", err)
+		}
+		var total float64
+		for i := 0; i < n; i++ {
+			part, err := g.Geometry(i)
+			if err != nil {
+				t.Fatalf("Geometry(%d) error = %v", i, err)
+			}
+			total += area(t, part)
+		}
+		return total
+	default:
+		return 0
+	}
+}
+
+// ringArea returns the unsigned shoelace area of a ring
+func ringArea(t *testing.T, ring *geom.Geometry) float64 {
+	t.Helper()
+	coords, err := ring.Coords()
+	if err != nil {
+		t.Fatalf("Coords() error = %v", err)
+	}
+
+	var sum float64
+	for i := 0; i+1 < len(coords); i++ {
+		sum += coords[i].X*coords[i+1].Y - coords[i+1].X*coords[i].Y
+	}
+	return math.Abs(sum) / 2
+}
+
+// parts returns the number of parts of a geometry - zero when empty
+func parts(t *testing.T, g *geom.Geometry) int {
+	t.Helper()
+	empty, err := g.IsEmpty()
+	if err != nil {
+		t.Fatalf("IsEmpty() error = %v", err)
+	}
+	if empty {
+		return 0
+	}
+	n, err := g.NGeometry()
+	if err != nil {
+		t.Fatalf("NGeometry() error = %v", err)
+	}
+	return n
+}
+
+func TestOverlay(t *testing.T) {
+	type overlayOp func(a, b *geom.Geometry) (*geom.Geometry, error)
+	ops := map[string]overlayOp{
+		"intersection":   (*geom.Geometry).Intersection,
+		"union":          (*geom.Geometry).Union,
+		"difference":     (*geom.Geometry).Difference,
+		"sym difference": (*geom.Geometry).SymDifference,
+	}
+
+	tests := []struct {
+		name  string
+		a, b  []geom.Coord
+		holeA []geom.Coord
+		op    string
+		area  float64
+		parts int
+	}{
+		{name: "overlapping squares", a: square(0, 0, 2), b: square(1, 1, 2), op: "intersection", area: 1, parts: 1},
+		{name: "overlapping squares", a: square(0, 0, 2), b: square(1, 1, 2), op: "union", area: 7, parts: 1},
+		{name: "overlapping squares", a: square(0, 0, 2), b: square(1, 1, 2), op: "difference", area: 3, parts: 1},
+		{name: "overlapping squares", a: square(0, 0, 2), b: square(1, 1, 2), op: "sym difference", area: 6, parts: 2},
+		{name: "disjoint squares", a: square(0, 0, 1), b: square(5, 5, 1), op: "intersection", area: 0, parts: 0},
+		{name: "disjoint squares", a: square(0, 0, 1), b: square(5, 5, 1), op: "union", area: 2, parts: 2},
+		{name: "nested squares", a: square(0, 0, 4), b: square(1, 1, 1), op: "difference", area: 15, parts: 1},
+		{name: "square with hole", a: square(0, 0, 4), holeA: square(1, 1, 2), b: square(0, 0, 2), op: "intersection", area: 3, parts: 1},
+		{name: "square with hole", a: square(0, 0, 4), holeA: square(1, 1, 2), b: square(1, 1, 2), op: "union", area: 16, parts: 1},
+		{name: "edge sharing squares", a: square(0, 0, 1), b: square(1, 0, 1), op: "union", area: 2, parts: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name+" "+test.op, func(t *testing.T) {
+			var holes [][]geom.Coord
+			if test.holeA != nil {
+				holes = append(holes, test.holeA)
+			}
+
+			result, err := ops[test.op](polygon(t, test.a, holes...), polygon(t, test.b))
+			if err != nil {
+				t.Fatalf("%s error = %v", test.op, err)
+			}
+			if got := area(t, result); math.Abs(got-test.area) > TOLERANCE {
+				t.Errorf("%s area = %v, want %v", test.op, got, test.area)
+			}
+			if got := parts(t, result); got != test.parts {
+				t.Errorf("%s parts = %d, want %d", test.op, got, test.parts)
+			}
+		})
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	type predicateOp func(a, b *geom.Geometry) (bool, error)
+	ops := map[string]predicateOp{
+		"intersects": (*geom.Geometry).Intersects,
+		"disjoint":   (*geom.Geometry).Disjoint,
+		"contains":   (*geom.Geometry).Contains,
+		"covers":     (*geom.Geometry).Covers,
+		"within":     (*geom.Geometry).Within,
+		"touches":    (*geom.Geometry).Touches,
+		"overlaps":   (*geom.Geometry).Overlaps,
+	}
+
+	tests := []struct {
+		name string
+		a, b func(t *testing.T) *geom.Geometry
+		want map[string]bool
+	}{
+		{
+			name: "overlapping squares",
+			a:    func(t *testing.T) *geom.Geometry { return polygon(t, square(0, 0, 2)) },
+			b:    func(t *testing.T) *geom.Geometry { return polygon(t, square(1, 1, 2)) },
+			want: map[string]bool{"intersects": true, "disjoint": false, "contains": false, "covers": false, "within": false, "touches": false, "overlaps": true},
+		},
+		{
+			name: "nested squares",
+			a:    func(t *testing.T) *geom.Geometry { return polygon(t, square(0, 0, 4)) },
+			b:    func(t *testing.T) *geom.Geometry { return polygon(t, square(1, 1, 1)) },
+			want: map[string]bool{"intersects": true, "disjoint": false, "contains": true, "covers": true, "within": false, "touches": false, "overlaps": false},
+		},
+		{
+			name: "edge sharing squares",
+			a:    func(t *testing.T) *geom.Geometry { return polygon(t, square(0, 0, 1)) },
+			b:    func(t *testing.T) *geom.Geometry { return polygon(t, square(1, 0, 1)) },
+			want: map[string]bool{"intersects": true, "disjoint": false, "contains": false, "covers": false, "within": false, "touches": true, "overlaps": false},
+		},
+		{
+			name: "point inside",
+			a:    func(t *testing.T) *geom.Geometry { return polygon(t, square(0, 0, 2)) },
+			b:    func(t *testing.T) *geom.Geometry { return pointAt(t, 1, 1) },
+			want: map[string]bool{"intersects": true, "disjoint": false, "contains": true, "covers": true, "within": false, "touches": false},
+		},
+		{
+			name: "point on boundary",
+			a:    func(t *testing.T) *geom.Geometry { return polygon(t, square(0, 0, 2)) },
+			b:    func(t *testing.T) *geom.Geometry { return pointAt(t, 2, 1) },
+			want: map[string]bool{"intersects": true, "disjoint": false, "contains": false, "covers": true, "within": false, "touches": true},
+		},
+		{
+			name: "corner touching squares",
+			a:    func(t *testing.T) *geom.Geometry { return polygon(t, square(0, 0, 1)) },
+			b:    func(t *testing.T) *geom.Geometry { return polygon(t, square(1, 1, 1)) },
+			want: map[string]bool{"intersects": true, "disjoint": false, "contains": false, "covers": false, "within": false, "touches": true, "overlaps": false},
+		},
+		{
+			name: "disjoint squares",
+			a:    func(t *testing.T) *geom.Geometry { return polygon(t, square(0, 0, 1)) },
+			b:    func(t *testing.T) *geom.Geometry { return polygon(t, square(5, 5, 1)) },
+			want: map[string]bool{"intersects": false, "disjoint": true, "contains": false, "covers": false, "within": false, "touches": false, "overlaps": false},
+		},
+		{
+			name: "square in hole",
+			a:    func(t *testing.T) *geom.Geometry { return polygon(t, square(0, 0, 4), square(1, 1, 2)) },
+			b:    func(t *testing.T) *geom.Geometry { return polygon(t, square(1.5, 1.5, 1)) },
+			want: map[string]bool{"intersects": false, "disjoint": true, "contains": false, "covers": false, "within": false, "touches": false, "overlaps": false},
+		},
+		{
+			name: "square around polygon with hole",
+			a:    func(t *testing.T) *geom.Geometry { return polygon(t, square(-1, -1, 6)) },
+			b:    func(t *testing.T) *geom.Geometry { return polygon(t, square(0, 0, 4), square(1, 1, 2)) },
+			want: map[string]bool{"intersects": true, "disjoint": false, "contains": true, "covers": true, "within": false, "touches": false, "overlaps": false},
+		},
+		{
+			name: "point in hole",
+			a:    func(t *testing.T) *geom.Geometry { return polygon(t, square(0, 0, 4), square(1, 1, 2)) },
+			b:    func(t *testing.T) *geom.Geometry { return pointAt(t, 2, 2) },
+			want: map[string]bool{"intersects": false, "disjoint": true, "contains": false, "covers": false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a, b := test.a(t), test.b(t)
+			for name, want := range test.want {
+				got, err := ops[name](a, b)
+				if err != nil {
+					t.Fatalf("%s error = %v", name, err)
+				}
+				if got != want {
+					t.Errorf("%s = %v, want %v", name, got, want)
+				}
+
+				// Prepared geometries answer the same
+				if name == "overlaps" {
+					continue
+				}
+				prepared := map[string]predicateOp{
+					"intersects": func(a, b *geom.Geometry) (bool, error) { return a.Prepare().Intersects(b) },
+					"disjoint":   func(a, b *geom.Geometry) (bool, error) { return a.Prepare().Disjoint(b) },
+					"contains":   func(a, b *geom.Geometry) (bool, error) { return a.Prepare().Contains(b) },
+					"covers":     func(a, b *geom.Geometry) (bool, error) { return a.Prepare().Covers(b) },
+					"within":     func(a, b *geom.Geometry) (bool, error) { return a.Prepare().Within(b) },
+					"touches":    func(a, b *geom.Geometry) (bool, error) { return a.Prepare().Touches(b) },
+				}
+				got, err = prepared[name](a, b)
+				if err != nil {
+					t.Fatalf("prepared %s error = %v", name, err)
+				}
+				if got != want {
+					t.Errorf("prepared %s = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRelate(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   []geom.Coord
+		matrix string
+	}{
+		{name: "overlapping squares", a: square(0, 0, 2), b: square(1, 1, 2), matrix: "212101212"},
+		{name: "nested squares", a: square(0, 0, 4), b: square(1, 1, 1), matrix: "212FF1FF2"},
+		{name: "edge sharing squares", a: square(0, 0, 1), b: square(1, 0, 1), matrix: "FF2F11212"},
+		{name: "disjoint squares", a: square(0, 0, 1), b: square(5, 5, 1), matrix: "FF2FF1212"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matrix, err := polygon(t, test.a).Relate(polygon(t, test.b))
+			if err != nil {
+				t.Fatalf("Relate() error = %v", err)
+			}
+			if matrix != test.matrix {
+				t.Errorf("Relate() = %s, want %s", matrix, test.matrix)
+			}
+		})
+	}
+}
+
+func TestConstructions(t *testing.T) {
+	lShape := []geom.Coord{
+		geom.NewCoord(0, 0),
+		geom.NewCoord(2, 0),
+		geom.NewCoord(2, 1),
+		geom.NewCoord(1, 1),
+		geom.NewCoord(1, 2),
+		geom.NewCoord(0, 2),
+		geom.NewCoord(0, 0),
+	}
+
+	hull, err := polygon(t, lShape).ConvexHull()
+	if err != nil {
+		t.Fatalf("ConvexHull() error = %v", err)
+	}
+	if got := area(t, hull); math.Abs(got-3.5) > TOLERANCE {
+		t.Errorf("ConvexHull() area = %v, want 3.5", got)
+	}
+
+	centroid, err := polygon(t, square(0, 0, 2)).Centroid()
+	if err != nil {
+		t.Fatalf("Centroid() error = %v", err)
+	}
+	coords, err := centroid.Coords()
+	if err != nil || len(coords) != 1 || math.Abs(coords[0].X-1) > TOLERANCE || math.Abs(coords[0].Y-1) > TOLERANCE {
+		t.Errorf("Centroid() = %v, %v, want (1 1)", coords, err)
+	}
+
+	surface, err := polygon(t, lShape).PointOnSurface()
+	if err != nil {
+		t.Fatalf("PointOnSurface() error = %v", err)
+	}
+	inside, err := polygon(t, lShape).Contains(surface)
+	if err != nil || !inside {
+		t.Errorf("PointOnSurface() = %v outside the polygon, %v", surface, err)
+	}
+
+	// Buffered circles are regular polygons of 8 segments a quadrant
+	buffered, err := pointAt(t, 0, 0).Buffer(1)
+	if err != nil {
+		t.Fatalf("Buffer() error = %v", err)
+	}
+	want := 16 * math.Sin(math.Pi/16)
+	if got := area(t, buffered); math.Abs(got-want) > 1e-3 {
+		t.Errorf("Buffer() area = %v, want %v", got, want)
+	}
+
+	// Grown shells gain a strip per side and a fan of 8 triangles per corner
+	// while grown holes keep their corners square
+	grown, err := polygon(t, square(0, 0, 4), square(1, 1, 2)).Buffer(0.5)
+	if err != nil {
+		t.Fatalf("Buffer() error = %v", err)
+	}
+	want = 16 + 4*4*0.5 + 4*8*0.5*0.5*0.5*math.Sin(math.Pi/16) - 1
+	if got := area(t, grown); math.Abs(got-want) > 1e-3 {
+		t.Errorf("Buffer() area = %v, want %v", got, want)
+	}
+
+	shrunk, err := polygon(t, square(0, 0, 4)).Buffer(-1)
+	if err != nil {
+		t.Fatalf("Buffer() error = %v", err)
+	}
+	if got := area(t, shrunk); math.Abs(got-4) > TOLERANCE {
+		t.Errorf("Buffer() area = %v, want 4", got)
+	}
+}
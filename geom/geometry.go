@@ -0,0 +1,481 @@
+//go:build purego
+// +build purego
+
+package geom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+var (
+	errNotPolygon     = errors.New("geometry must be a polygon")
+	errNotSequence    = errors.New("geometry must be a point, line string or linear ring")
+	errOutOfRange     = errors.New("geometry index out of range")
+	errUnsupported    = errors.New("unsupported geometry type for the operation")
+	errCollectionType = errors.New("geometry does not match the collection type")
+)
+
+// Coord is a coordinate of the plane - Z is kept for parity with GEOS and
+// ignored by every operation
+type Coord struct {
+	X, Y, Z float64
+}
+
+// NewCoord creates a coordinate of the plane
+func NewCoord(x, y float64) Coord {
+	return Coord{x, y, 0}
+}
+
+// String returns the coordinate as x y
+func (c Coord) String() string {
+	return fmt.Sprintf("%f %f", c.X, c.Y)
+}
+
+// GeometryType is the OGC simple features type of a geometry - the values
+// match the GEOS C API
+type GeometryType int
+
+const (
+	POINT GeometryType = iota
+	LINESTRING
+	LINEARRING
+	POLYGON
+	MULTIPOINT
+	MULTILINESTRING
+	MULTIPOLYGON
+	GEOMETRYCOLLECTION
+)
+
+var geometryTypes = map[GeometryType]string{
+	POINT:              "Point",
+	LINESTRING:         "LineString",
+	LINEARRING:         "LinearRing",
+	POLYGON:            "Polygon",
+	MULTIPOINT:         "MultiPoint",
+	MULTILINESTRING:    "MultiLineString",
+	MULTIPOLYGON:       "MultiPolygon",
+	GEOMETRYCOLLECTION: "GeometryCollection",
+}
+
+func (t GeometryType) String() string {
+	return geometryTypes[t]
+}
+
+// CapStyle is the style of the cap at the end of a buffered line
+type CapStyle int
+
+const (
+	_ CapStyle = iota
+	CapRound
+	CapFlat
+	CapSquare
+)
+
+// JoinStyle is the style of the join of two buffered segments
+type JoinStyle int
+
+const (
+	_ JoinStyle = iota
+	JoinRound
+	JoinMitre
+	JoinBevel
+)
+
+// BufferOpts are the quadrant segments, end cap and join styles of a buffer
+type BufferOpts struct {
+	// QuadSegs is the number of segments per quarter circle
+	QuadSegs int
+	// CapStyle is the end cap style
+	CapStyle CapStyle
+	// JoinStyle is the segment join style
+	JoinStyle JoinStyle
+	// MitreLimit is the longest mitre as a multiple of the buffer width
+	MitreLimit float64
+}
+
+// Geometry is an immutable geometry of the plane - safe to share across
+// goroutines
+type Geometry struct {
+	typ    GeometryType
+	coords []Coord     // points, line strings and linear rings
+	rings  []*Geometry // polygon shell then holes
+	parts  []*Geometry // collections
+}
+
+// NewPoint creates a point - empty without coordinates
+func NewPoint(coords ...Coord) (*Geometry, error) {
+	if len(coords) > 1 {
+		return nil, fmt.Errorf("NewPoint: %d coordinates given - must be 0 or 1", len(coords))
+	}
+	return &Geometry{typ: POINT, coords: copyCoords(coords)}, nil
+}
+
+// NewLineString creates a line string - empty without coordinates
+func NewLineString(coords ...Coord) (*Geometry, error) {
+	if len(coords) == 1 {
+		return nil, errors.New("NewLineString: invalid number of points in line string found 1 - must be 0 or >= 2")
+	}
+	return &Geometry{typ: LINESTRING, coords: copyCoords(coords)}, nil
+}
+
+// NewLinearRing creates a closed linear ring - empty without coordinates
+func NewLinearRing(coords ...Coord) (*Geometry, error) {
+	if len(coords) > 0 && len(coords) < 4 {
+		return nil, fmt.Errorf("NewLinearRing: invalid number of points in linear ring found %d - must be 0 or >= 4", len(coords))
+	}
+	if len(coords) > 0 && !samePoint(coords[0], coords[len(coords)-1]) {
+		return nil, errors.New("NewLinearRing: points of linear ring do not form a closed line string")
+	}
+	return &Geometry{typ: LINEARRING, coords: copyCoords(coords)}, nil
+}
+
+// NewPolygon creates a polygon from a closed shell and closed holes
+func NewPolygon(shell []Coord, holes ...[]Coord) (*Geometry, error) {
+	ext, err := NewLinearRing(shell...)
+	if err != nil {
+		return nil, err
+	}
+	if len(shell) == 0 {
+		if len(holes) > 0 {
+			return nil, errors.New("NewPolygon: empty shell with holes")
+		}
+		return &Geometry{typ: POLYGON}, nil
+	}
+
+	rings := []*Geometry{ext}
+	for _, hole := range holes {
+		ring, err := NewLinearRing(hole...)
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, ring)
+	}
+
+	return &Geometry{typ: POLYGON, rings: rings}, nil
+}
+
+// NewCollection creates a collection of the type holding the geometries
+func NewCollection(collectionType GeometryType, geoms ...*Geometry) (*Geometry, error) {
+	var member GeometryType
+	switch collectionType {
+	case MULTIPOINT:
+		member = POINT
+	case MULTILINESTRING:
+		member = LINESTRING
+	case MULTIPOLYGON:
+		member = POLYGON
+	case GEOMETRYCOLLECTION:
+	default:
+		return nil, fmt.Errorf("NewCollection: %w: %v", errCollectionType, collectionType)
+	}
+
+	for _, g := range geoms {
+		if collectionType != GEOMETRYCOLLECTION && g.typ != member && !(member == LINESTRING && g.typ == LINEARRING) {
+			return nil, fmt.Errorf("NewCollection: %w: %v in %v", errCollectionType, g.typ, collectionType)
+		}
+	}
+
+	return &Geometry{typ: collectionType, parts: append([]*Geometry(nil), geoms...)}, nil
+}
+
+// Must panics on the error of a geometry constructor or operation
+func Must(g *Geometry, err error) *Geometry {
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// Type returns the geometry type
+func (g *Geometry) Type() (GeometryType, error) {
+	return g.typ, nil
+}
+
+// IsEmpty returns true if the geometry has no coordinates
+func (g *Geometry) IsEmpty() (bool, error) {
+	return g.empty(), nil
+}
+
+func (g *Geometry) empty() bool {
+	switch g.typ {
+	case POLYGON:
+		return len(g.rings) == 0 || len(g.rings[0].coords) == 0
+	case MULTIPOINT, MULTILINESTRING, MULTIPOLYGON, GEOMETRYCOLLECTION:
+		for _, part := range g.parts {
+			if !part.empty() {
+				return false
+			}
+		}
+		return true
+	default:
+		return len(g.coords) == 0
+	}
+}
+
+// NGeometry returns the number of parts of a collection - one for any other
+// non-empty geometry
+func (g *Geometry) NGeometry() (int, error) {
+	if g.collection() {
+		return len(g.parts), nil
+	}
+	if g.empty() {
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// Geometry returns the nth part of a collection - the geometry itself for any
+// other geometry
+func (g *Geometry) Geometry(n int) (*Geometry, error) {
+	if g.collection() {
+		if n < 0 || n >= len(g.parts) {
+			return nil, fmt.Errorf("Geometry: %w: %d", errOutOfRange, n)
+		}
+		return g.parts[n], nil
+	}
+	if n != 0 {
+		return nil, fmt.Errorf("Geometry: %w: %d", errOutOfRange, n)
+	}
+	return g, nil
+}
+
+// Shell returns the exterior ring of a polygon
+func (g *Geometry) Shell() (*Geometry, error) {
+	if g.typ != POLYGON {
+		return nil, fmt.Errorf("Shell: %w", errNotPolygon)
+	}
+	if len(g.rings) == 0 {
+		return &Geometry{typ: LINEARRING}, nil
+	}
+	return g.rings[0], nil
+}
+
+// Holes returns the interior rings of a polygon
+func (g *Geometry) Holes() ([]*Geometry, error) {
+	if g.typ != POLYGON {
+		return nil, fmt.Errorf("Holes: %w", errNotPolygon)
+	}
+	if len(g.rings) < 2 {
+		return []*Geometry{}, nil
+	}
+	return append([]*Geometry(nil), g.rings[1:]...), nil
+}
+
+// Coords returns the coordinates of a point, line string or linear ring
+func (g *Geometry) Coords() ([]Coord, error) {
+	switch g.typ {
+	case POINT, LINESTRING, LINEARRING:
+		return copyCoords(g.coords), nil
+	default:
+		return nil, fmt.Errorf("Coords: %w", errNotSequence)
+	}
+}
+
+// Prepare returns the geometry indexed for repeated predicates
+func (g *Geometry) Prepare() *PGeometry {
+	return &PGeometry{g: g, parts: g.components()}
+}
+
+// String returns the geometry as WKT
+func (g *Geometry) String() string {
+	var b strings.Builder
+	writeWKT(&b, g, true)
+	return b.String()
+}
+
+func (g *Geometry) collection() bool {
+	switch g.typ {
+	case MULTIPOINT, MULTILINESTRING, MULTIPOLYGON, GEOMETRYCOLLECTION:
+		return true
+	}
+	return false
+}
+
+func writeWKT(b *strings.Builder, g *Geometry, tagged bool) {
+	if tagged {
+		b.WriteString(strings.ToUpper(g.typ.String()))
+		b.WriteString(" ")
+	}
+	if g.empty() {
+		b.WriteString("EMPTY")
+		return
+	}
+
+	b.WriteString("(")
+	switch g.typ {
+	case POINT, LINESTRING, LINEARRING:
+		for i, c := range g.coords {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(b, "%v %v", c.X, c.Y)
+		}
+	case POLYGON:
+		for i, ring := range g.rings {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writeWKT(b, ring, false)
+		}
+	default:
+		for i, part := range g.parts {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			writeWKT(b, part, g.typ == GEOMETRYCOLLECTION)
+		}
+	}
+	b.WriteString(")")
+}
+
+// components are the parts of one or more geometries by dimension - areal
+// rings are closed with shells wound counterclockwise and holes clockwise
+type components struct {
+	rings  [][]Coord
+	lines  [][]Coord
+	points []Coord
+}
+
+func (g *Geometry) components() *components {
+	c := &components{}
+	c.add(g)
+	return c
+}
+
+func (c *components) add(g *Geometry) {
+	if g.empty() {
+		return
+	}
+
+	switch g.typ {
+	case POINT:
+		c.points = append(c.points, g.coords[0])
+	case LINESTRING, LINEARRING:
+		c.lines = append(c.lines, g.coords)
+	case POLYGON:
+		for i, ring := range g.rings {
+			if len(ring.coords) == 0 {
+				continue
+			}
+			// Shells to the left of their edges and holes to the right
+			if (signedArea(ring.coords) < 0) == (i == 0) {
+				c.rings = append(c.rings, reversed(ring.coords))
+			} else {
+				c.rings = append(c.rings, ring.coords)
+			}
+		}
+	default:
+		for _, part := range g.parts {
+			c.add(part)
+		}
+	}
+}
+
+// dimension is the highest dimension of the parts - -1 when empty
+func (c *components) dimension() int {
+	switch {
+	case len(c.rings) > 0:
+		return 2
+	case len(c.lines) > 0:
+		return 1
+	case len(c.points) > 0:
+		return 0
+	}
+	return -1
+}
+
+// segments are the edges of the rings and lines tagged with the operand
+func (c *components) segments(operand int) []segment {
+	var segments []segment
+	for _, ring := range c.rings {
+		for i := 0; i+1 < len(ring); i++ {
+			segments = append(segments, segment{a: ring[i], b: ring[i+1], operand: operand})
+		}
+	}
+	for _, line := range c.lines {
+		for i := 0; i+1 < len(line); i++ {
+			segments = append(segments, segment{a: line[i], b: line[i+1], operand: operand, line: true})
+		}
+	}
+	return segments
+}
+
+// bounds is the box around every part
+func (c *components) bounds() box {
+	b := box{min: Coord{X: math.Inf(1), Y: math.Inf(1)}, max: Coord{X: math.Inf(-1), Y: math.Inf(-1)}}
+	extend := func(p Coord) {
+		b = b.union(box{min: p, max: p})
+	}
+	for _, ring := range c.rings {
+		for _, p := range ring {
+			extend(p)
+		}
+	}
+	for _, line := range c.lines {
+		for _, p := range line {
+			extend(p)
+		}
+	}
+	for _, p := range c.points {
+		extend(p)
+	}
+	return b
+}
+
+// signedArea is positive for counterclockwise closed rings
+func signedArea(ring []Coord) float64 {
+	if len(ring) < 3 {
+		return 0
+	}
+	// Relative to the first point to keep precision far from the origin
+	origin := ring[0]
+	var sum float64
+	for i := 1; i < len(ring)-1; i++ {
+		a, b := ring[i], ring[i+1]
+		sum += (a.X-origin.X)*(b.Y-origin.Y) - (b.X-origin.X)*(a.Y-origin.Y)
+	}
+	return sum / 2.0
+}
+
+func reversed(coords []Coord) []Coord {
+	out := make([]Coord, len(coords))
+	for i, c := range coords {
+		out[len(coords)-1-i] = c
+	}
+	return out
+}
+
+func copyCoords(coords []Coord) []Coord {
+	if len(coords) == 0 {
+		return nil
+	}
+	return append([]Coord(nil), coords...)
+}
+
+func samePoint(a, b Coord) bool {
+	return a.X == b.X && a.Y == b.Y
+}
+
+// polygonal builds a polygon or multi polygon of closed rings - an empty
+// polygon when there are none
+func polygonal(polygons [][][]Coord) *Geometry {
+	geoms := make([]*Geometry, 0, len(polygons))
+	for _, rings := range polygons {
+		polygon := &Geometry{typ: POLYGON}
+		for _, ring := range rings {
+			polygon.rings = append(polygon.rings, &Geometry{typ: LINEARRING, coords: ring})
+		}
+		geoms = append(geoms, polygon)
+	}
+
+	switch len(geoms) {
+	case 0:
+		return &Geometry{typ: POLYGON}
+	case 1:
+		return geoms[0]
+	}
+	return &Geometry{typ: MULTIPOLYGON, parts: geoms}
+}
@@ -0,0 +1,87 @@
+//go:build !purego
+// +build !purego
+
+package geom
+
+import (
+	"github.com/jdejesus007/gogeos/geos"
+)
+
+// Geometry is a GEOS geometry
+type Geometry = geos.Geometry
+
+// PGeometry is a GEOS prepared geometry
+type PGeometry = geos.PGeometry
+
+// Coord is a coordinate of the plane
+type Coord = geos.Coord
+
+// GeometryType is the OGC simple features type of a geometry
+type GeometryType = geos.GeometryType
+
+// CapStyle is the style of the cap at the end of a buffered line
+type CapStyle = geos.CapStyle
+
+// JoinStyle is the style of the join of two buffered segments
+type JoinStyle = geos.JoinStyle
+
+// BufferOpts are the quadrant segments, end cap and join styles of a buffer
+type BufferOpts = geos.BufferOpts
+
+const (
+	POINT              = geos.POINT
+	LINESTRING         = geos.LINESTRING
+	LINEARRING         = geos.LINEARRING
+	POLYGON            = geos.POLYGON
+	MULTIPOINT         = geos.MULTIPOINT
+	MULTILINESTRING    = geos.MULTILINESTRING
+	MULTIPOLYGON       = geos.MULTIPOLYGON
+	GEOMETRYCOLLECTION = geos.GEOMETRYCOLLECTION
+)
+
+const (
+	CapRound  = geos.CapRound
+	CapFlat   = geos.CapFlat
+	CapSquare = geos.CapSquare
+)
+
+const (
+	JoinRound = geos.JoinRound
+	JoinMitre = geos.JoinMitre
+	JoinBevel = geos.JoinBevel
+)
+
+// NewCoord creates a coordinate of the plane
+func NewCoord(x, y float64) Coord {
+	return geos.NewCoord(x, y)
+}
+
+// NewPoint creates a point - empty without coordinates
+func NewPoint(coords ...Coord) (*Geometry, error) {
+	return geos.NewPoint(coords...)
+}
+
+// NewLineString creates a line string - empty without coordinates
+func NewLineString(coords ...Coord) (*Geometry, error) {
+	return geos.NewLineString(coords...)
+}
+
+// NewLinearRing creates a closed linear ring - empty without coordinates
+func NewLinearRing(coords ...Coord) (*Geometry, error) {
+	return geos.NewLinearRing(coords...)
+}
+
+// NewPolygon creates a polygon from a closed shell and closed holes
+func NewPolygon(shell []Coord, holes ...[]Coord) (*Geometry, error) {
+	return geos.NewPolygon(shell, holes...)
+}
+
+// NewCollection creates a collection of the type holding the geometries
+func NewCollection(collectionType GeometryType, geoms ...*Geometry) (*Geometry, error) {
+	return geos.NewCollection(collectionType, geoms...)
+}
+
+// Must panics on the error of a geometry constructor or operation
+func Must(g *Geometry, err error) *Geometry {
+	return geos.Must(g, err)
+}
@@ -0,0 +1,224 @@
+//go:build purego
+// +build purego
+
+package geom
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// STR_NODE_CAPACITY is the number of boxes or child nodes grouped under
+	// each node of the segment index
+	STR_NODE_CAPACITY = 16
+)
+
+// strNode bounds a range of the level below - of the items at the leaves
+type strNode struct {
+	bounds     box
+	start, end int
+}
+
+// strTree is a packed R-tree of boxes sorted tile by tile (STR) - built once
+// and queried for the boxes overlapping a box on both axes
+type strTree struct {
+	boxes []box
+	// items are the box indexes in leaf order
+	items []int
+	// levels run from the leaves up to the root nodes
+	levels [][]strNode
+}
+
+func newSTRTree(boxes []box) *strTree {
+	t := &strTree{boxes: boxes, items: make([]int, len(boxes))}
+	for i := range t.items {
+		t.items[i] = i
+	}
+	if len(boxes) == 0 {
+		return t
+	}
+
+	strSort(t.items, func(i int) box { return boxes[i] })
+	level := make([]strNode, 0, len(boxes)/STR_NODE_CAPACITY+1)
+	for start := 0; start < len(t.items); start += STR_NODE_CAPACITY {
+		end := start + STR_NODE_CAPACITY
+		if end > len(t.items) {
+			end = len(t.items)
+		}
+		n := strNode{bounds: boxes[t.items[start]], start: start, end: end}
+		for _, i := range t.items[start+1 : end] {
+			n.bounds = n.bounds.union(boxes[i])
+		}
+		level = append(level, n)
+	}
+
+	for {
+		t.levels = append(t.levels, level)
+		if len(level) <= STR_NODE_CAPACITY {
+			return t
+		}
+
+		// Reorder the level so each parent bounds a contiguous tile of it
+		order := make([]int, len(level))
+		for i := range order {
+			order[i] = i
+		}
+		strSort(order, func(i int) box { return level[i].bounds })
+		sorted := make([]strNode, len(level))
+		for i, j := range order {
+			sorted[i] = level[j]
+		}
+		t.levels[len(t.levels)-1] = sorted
+
+		parents := make([]strNode, 0, len(sorted)/STR_NODE_CAPACITY+1)
+		for start := 0; start < len(sorted); start += STR_NODE_CAPACITY {
+			end := start + STR_NODE_CAPACITY
+			if end > len(sorted) {
+				end = len(sorted)
+			}
+			n := strNode{bounds: sorted[start].bounds, start: start, end: end}
+			for _, child := range sorted[start+1 : end] {
+				n.bounds = n.bounds.union(child.bounds)
+			}
+			parents = append(parents, n)
+		}
+		level = parents
+	}
+}
+
+// strSort orders entries into vertical slices by the center x of their boxes
+// and each slice by center y - neighbouring runs of STR_NODE_CAPACITY entries
+// then form compact tiles
+func strSort(entries []int, bounds func(int) box) {
+	// Entries index the boxes from zero
+	centers := make([]Coord, len(entries))
+	for _, i := range entries {
+		b := bounds(i)
+		centers[i] = Coord{X: (b.min.X + b.max.X) / 2.0, Y: (b.min.Y + b.max.Y) / 2.0}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return centers[entries[i]].X < centers[entries[j]].X })
+
+	tiles := int(math.Ceil(float64(len(entries)) / STR_NODE_CAPACITY))
+	sliceSize := int(math.Ceil(math.Sqrt(float64(tiles)))) * STR_NODE_CAPACITY
+	for start := 0; start < len(entries); start += sliceSize {
+		end := start + sliceSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+		slice := entries[start:end]
+		sort.Slice(slice, func(i, j int) bool { return centers[slice[i]].Y < centers[slice[j]].Y })
+	}
+}
+
+// eachPair calls fn with every pair of box indexes i < j whose boxes overlap
+// - joining the tree with itself so only overlapping nodes are paired
+func (t *strTree) eachPair(fn func(i, j int)) {
+	if len(t.levels) == 0 {
+		return
+	}
+
+	top := len(t.levels) - 1
+	for i := range t.levels[top] {
+		for j := i; j < len(t.levels[top]); j++ {
+			t.join(top, i, j, fn)
+		}
+	}
+}
+
+func (t *strTree) join(level, i, j int, fn func(i, j int)) {
+	a, b := t.levels[level][i], t.levels[level][j]
+	if !a.bounds.overlaps(b.bounds) {
+		return
+	}
+
+	if level > 0 {
+		for ci := a.start; ci < a.end; ci++ {
+			cj := b.start
+			if i == j {
+				cj = ci
+			}
+			for ; cj < b.end; cj++ {
+				t.join(level-1, ci, cj, fn)
+			}
+		}
+		return
+	}
+
+	for x := a.start; x < a.end; x++ {
+		y := b.start
+		if i == j {
+			y = x + 1
+		}
+		bx := t.boxes[t.items[x]]
+		for ; y < b.end; y++ {
+			if !bx.overlaps(t.boxes[t.items[y]]) {
+				continue
+			}
+			if p, q := t.items[x], t.items[y]; p < q {
+				fn(p, q)
+			} else {
+				fn(q, p)
+			}
+		}
+	}
+}
+
+// query calls fn with every box index whose box overlaps b - stops early once
+// fn returns false. Returns false if it stopped early
+func (t *strTree) query(b box, fn func(i int) bool) bool {
+	if len(t.levels) == 0 {
+		return true
+	}
+
+	top := len(t.levels) - 1
+	for i := range t.levels[top] {
+		if !t.descend(top, i, b, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *strTree) descend(level, i int, b box, fn func(i int) bool) bool {
+	n := t.levels[level][i]
+	if !n.bounds.overlaps(b) {
+		return true
+	}
+
+	if level == 0 {
+		for _, item := range t.items[n.start:n.end] {
+			if t.boxes[item].overlaps(b) && !fn(item) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for child := n.start; child < n.end; child++ {
+		if !t.descend(level-1, child, b, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// overlaps is true if the boxes share a point
+func (b box) overlaps(other box) bool {
+	return b.min.X <= other.max.X && other.min.X <= b.max.X &&
+		b.min.Y <= other.max.Y && other.min.Y <= b.max.Y
+}
+
+// union is the box around both boxes
+func (b box) union(other box) box {
+	return box{
+		min: Coord{X: math.Min(b.min.X, other.min.X), Y: math.Min(b.min.Y, other.min.Y)},
+		max: Coord{X: math.Max(b.max.X, other.max.X), Y: math.Max(b.max.Y, other.max.Y)},
+	}
+}
+
+// expand is the box grown by d on every side
+func (b box) expand(d float64) box {
+	return box{min: Coord{X: b.min.X - d, Y: b.min.Y - d}, max: Coord{X: b.max.X + d, Y: b.max.Y + d}}
+}
@@ -0,0 +1,380 @@
+//go:build purego
+// +build purego
+
+package geom
+
+import (
+	"math"
+	"math/big"
+	"sort"
+)
+
+const (
+	// SNAP_TOLERANCE is the distance relative to the coordinate magnitude
+	// within which nodes are merged and snapped onto the segments they touch
+	SNAP_TOLERANCE = 1e-11
+	// MAX_NODING_PASSES bounds the passes splitting segments that cross again
+	// after their nodes were rounded
+	MAX_NODING_PASSES = 5
+	// orientation error bound of the floating point determinant
+	orientationBound = 3.3306690738754716e-16
+	// exactPrecision holds any product of float64 differences exactly
+	exactPrecision = 4400
+)
+
+// orientation is 1 if c lies left of the line a to b, -1 if right and 0 if
+// collinear - exact for nearly collinear points
+func orientation(a, b, c Coord) int {
+	detLeft := (b.X - a.X) * (c.Y - a.Y)
+	detRight := (b.Y - a.Y) * (c.X - a.X)
+	det := detLeft - detRight
+
+	bound := orientationBound * (math.Abs(detLeft) + math.Abs(detRight))
+	switch {
+	case det > bound:
+		return 1
+	case det < -bound:
+		return -1
+	case detLeft == 0 && detRight == 0:
+		return 0
+	}
+
+	return exactOrientation(a, b, c)
+}
+
+func exactOrientation(a, b, c Coord) int {
+	diff := func(p, q float64) *big.Float {
+		return new(big.Float).SetPrec(exactPrecision).Sub(big.NewFloat(p), big.NewFloat(q))
+	}
+	left := new(big.Float).SetPrec(exactPrecision).Mul(diff(b.X, a.X), diff(c.Y, a.Y))
+	right := new(big.Float).SetPrec(exactPrecision).Mul(diff(b.Y, a.Y), diff(c.X, a.X))
+
+	return left.Cmp(right)
+}
+
+// segmentIntersection returns the points two segments share - none, the
+// crossing or touching point, or the ends of a collinear overlap
+func segmentIntersection(p1, p2, q1, q2 Coord) []Coord {
+	if !boxesOverlap(p1, p2, q1, q2) {
+		return nil
+	}
+
+	o1 := orientation(p1, p2, q1)
+	o2 := orientation(p1, p2, q2)
+	o3 := orientation(q1, q2, p1)
+	o4 := orientation(q1, q2, p2)
+
+	if o1*o2 > 0 || o3*o4 > 0 {
+		return nil
+	}
+
+	if o1 == 0 && o2 == 0 && o3 == 0 && o4 == 0 {
+		var shared []Coord
+		for _, c := range []Coord{p1, p2} {
+			if inBox(c, q1, q2) {
+				shared = appendDistinct(shared, c)
+			}
+		}
+		for _, c := range []Coord{q1, q2} {
+			if inBox(c, p1, p2) {
+				shared = appendDistinct(shared, c)
+			}
+		}
+		return shared
+	}
+
+	// Touching at an endpoint keeps the exact endpoint
+	var touching []Coord
+	if o1 == 0 {
+		touching = appendDistinct(touching, q1)
+	}
+	if o2 == 0 {
+		touching = appendDistinct(touching, q2)
+	}
+	if o3 == 0 {
+		touching = appendDistinct(touching, p1)
+	}
+	if o4 == 0 {
+		touching = appendDistinct(touching, p2)
+	}
+	if len(touching) > 0 {
+		return touching
+	}
+
+	return []Coord{crossing(p1, p2, q1, q2)}
+}
+
+// crossing is the point where two properly crossing segments meet - computed
+// relative to the first point and clamped to the shared box
+func crossing(p1, p2, q1, q2 Coord) Coord {
+	dpx, dpy := p2.X-p1.X, p2.Y-p1.Y
+	dqx, dqy := q2.X-q1.X, q2.Y-q1.Y
+	t := ((q1.X-p1.X)*dqy - (q1.Y-p1.Y)*dqx) / (dpx*dqy - dpy*dqx)
+
+	x := p1.X + t*dpx
+	y := p1.Y + t*dpy
+
+	x = math.Max(x, math.Max(math.Min(p1.X, p2.X), math.Min(q1.X, q2.X)))
+	x = math.Min(x, math.Min(math.Max(p1.X, p2.X), math.Max(q1.X, q2.X)))
+	y = math.Max(y, math.Max(math.Min(p1.Y, p2.Y), math.Min(q1.Y, q2.Y)))
+	y = math.Min(y, math.Min(math.Max(p1.Y, p2.Y), math.Max(q1.Y, q2.Y)))
+
+	return Coord{X: x, Y: y}
+}
+
+func boxesOverlap(p1, p2, q1, q2 Coord) bool {
+	return segment{a: p1, b: p2}.box().overlaps(segment{a: q1, b: q2}.box())
+}
+
+// inBox is true if collinear c lies on the segment a to b
+func inBox(c, a, b Coord) bool {
+	return c.X >= math.Min(a.X, b.X) && c.X <= math.Max(a.X, b.X) &&
+		c.Y >= math.Min(a.Y, b.Y) && c.Y <= math.Max(a.Y, b.Y)
+}
+
+func appendDistinct(coords []Coord, c Coord) []Coord {
+	for _, existing := range coords {
+		if samePoint(existing, c) {
+			return coords
+		}
+	}
+	return append(coords, c)
+}
+
+// segmentDistance is the distance from c to the segment a to b
+func segmentDistance(c, a, b Coord) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	length2 := dx*dx + dy*dy
+	if length2 == 0 {
+		return math.Hypot(c.X-a.X, c.Y-a.Y)
+	}
+	t := math.Max(0, math.Min(1, ((c.X-a.X)*dx+(c.Y-a.Y)*dy)/length2))
+	return math.Hypot(c.X-(a.X+t*dx), c.Y-(a.Y+t*dy))
+}
+
+// segment is an input edge of one of the two operands
+type segment struct {
+	a, b    Coord
+	operand int
+	line    bool
+}
+
+// box is the bounds of a segment
+type box struct {
+	min, max Coord
+}
+
+func (s segment) box() box {
+	b := box{min: s.a, max: s.b}
+	if b.min.X > b.max.X {
+		b.min.X, b.max.X = b.max.X, b.min.X
+	}
+	if b.min.Y > b.max.Y {
+		b.min.Y, b.max.Y = b.max.Y, b.min.Y
+	}
+	return b
+}
+
+// eachCrossing calls fn with the indexes of every pair of segments whose boxes
+// overlap - each pair once with i < j, found by joining an STR tree of the
+// boxes with itself
+func eachCrossing(segments []segment, fn func(i, j int)) {
+	boxes := make([]box, len(segments))
+	for i, s := range segments {
+		boxes[i] = s.box()
+	}
+
+	newSTRTree(boxes).eachPair(fn)
+}
+
+// nodeSet merges coordinates within the snap tolerance into one node
+type nodeSet struct {
+	tolerance float64
+	coords    []Coord
+	cells     map[[2]int64][]int
+	index     map[Coord]int
+}
+
+func newNodeSet(tolerance float64) *nodeSet {
+	return &nodeSet{tolerance: tolerance, cells: make(map[[2]int64][]int), index: make(map[Coord]int)}
+}
+
+func (n *nodeSet) cell(c Coord) [2]int64 {
+	return [2]int64{int64(math.Floor(c.X / n.tolerance)), int64(math.Floor(c.Y / n.tolerance))}
+}
+
+// add returns the node of the coordinate - an existing node within the
+// tolerance or a new one
+func (n *nodeSet) add(c Coord) int {
+	c.Z = 0
+	if i, ok := n.index[c]; ok {
+		return i
+	}
+
+	key := n.cell(c)
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for _, i := range n.cells[[2]int64{key[0] + dx, key[1] + dy}] {
+				if math.Hypot(n.coords[i].X-c.X, n.coords[i].Y-c.Y) <= n.tolerance {
+					n.index[c] = i
+					return i
+				}
+			}
+		}
+	}
+
+	i := len(n.coords)
+	n.coords = append(n.coords, c)
+	n.cells[key] = append(n.cells[key], i)
+	n.index[c] = i
+	return i
+}
+
+// edge is a noded segment between two nodes
+type edge struct {
+	from, to int
+	operand  int
+	line     bool
+}
+
+// node splits the segments at every crossing, touching point and point
+// component into edges between merged nodes
+func node(segments []segment, points []Coord, tolerance float64) (*nodeSet, []edge) {
+	nodes := newNodeSet(tolerance)
+
+	// Input vertices take precedence over crossings when merging
+	for _, s := range segments {
+		nodes.add(s.a)
+		nodes.add(s.b)
+	}
+	for _, p := range points {
+		nodes.add(p)
+	}
+
+	eachCrossing(segments, func(i, j int) {
+		for _, c := range segmentIntersection(segments[i].a, segments[i].b, segments[j].a, segments[j].b) {
+			nodes.add(c)
+		}
+	})
+
+	var edges []edge
+	for pass := 0; pass < MAX_NODING_PASSES; pass++ {
+		edges = split(segments, nodes)
+
+		// Rounded nodes can leave split edges crossing - node those too
+		sub := make([]segment, len(edges))
+		for i, e := range edges {
+			sub[i] = segment{a: nodes.coords[e.from], b: nodes.coords[e.to]}
+		}
+		crossed := false
+		eachCrossing(sub, func(i, j int) {
+			ei, ej := edges[i], edges[j]
+			if ei.from == ej.from || ei.from == ej.to || ei.to == ej.from || ei.to == ej.to {
+				return
+			}
+			for _, c := range segmentIntersection(sub[i].a, sub[i].b, sub[j].a, sub[j].b) {
+				before := len(nodes.coords)
+				nodes.add(c)
+				if len(nodes.coords) > before {
+					crossed = true
+				}
+			}
+		})
+		if !crossed {
+			break
+		}
+	}
+
+	return nodes, edges
+}
+
+// split cuts every segment at the nodes within the tolerance of it
+func split(segments []segment, nodes *nodeSet) []edge {
+	boxes := make([]box, len(nodes.coords))
+	for i, c := range nodes.coords {
+		boxes[i] = box{min: c, max: c}
+	}
+	tree := newSTRTree(boxes)
+
+	var edges []edge
+	for _, s := range segments {
+		from, to := nodes.add(s.a), nodes.add(s.b)
+		if from == to {
+			continue
+		}
+
+		a, b := nodes.coords[from], nodes.coords[to]
+		dx, dy := b.X-a.X, b.Y-a.Y
+		length2 := dx*dx + dy*dy
+
+		type stop struct {
+			t    float64
+			node int
+		}
+		var stops []stop
+
+		bounds := segment{a: a, b: b}.box().expand(nodes.tolerance)
+		tolerance2 := nodes.tolerance * nodes.tolerance
+		tree.query(bounds, func(n int) bool {
+			if n == from || n == to {
+				return true
+			}
+
+			// Only nodes strictly between the ends and within the tolerance of
+			// the line stop the segment
+			c := nodes.coords[n]
+			t := ((c.X-a.X)*dx + (c.Y-a.Y)*dy) / length2
+			if t <= 0 || t >= 1 {
+				return true
+			}
+			if cross := dx*(c.Y-a.Y) - dy*(c.X-a.X); cross*cross > tolerance2*length2 {
+				return true
+			}
+			stops = append(stops, stop{t, n})
+			return true
+		})
+		sort.Slice(stops, func(i, j int) bool {
+			if stops[i].t != stops[j].t {
+				return stops[i].t < stops[j].t
+			}
+			return stops[i].node < stops[j].node
+		})
+
+		prev := from
+		for _, st := range stops {
+			if st.node != prev {
+				edges = append(edges, edge{from: prev, to: st.node, operand: s.operand, line: s.line})
+				prev = st.node
+			}
+		}
+		if prev != to {
+			edges = append(edges, edge{from: prev, to: to, operand: s.operand, line: s.line})
+		}
+	}
+
+	return edges
+}
+
+// tolerance is the snap tolerance for the magnitude of the coordinates
+func tolerance(parts ...*components) float64 {
+	scale := 1.0
+	extend := func(c Coord) {
+		scale = math.Max(scale, math.Max(math.Abs(c.X), math.Abs(c.Y)))
+	}
+	for _, c := range parts {
+		for _, ring := range c.rings {
+			for _, p := range ring {
+				extend(p)
+			}
+		}
+		for _, line := range c.lines {
+			for _, p := range line {
+				extend(p)
+			}
+		}
+		for _, p := range c.points {
+			extend(p)
+		}
+	}
+	return scale * SNAP_TOLERANCE
+}
@@ -0,0 +1,716 @@
+//go:build purego
+// +build purego
+
+package geom
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// location of a point relative to a geometry
+type location int
+
+const (
+	interior location = iota
+	boundary
+	exterior
+)
+
+// group is every edge between the same two nodes - from is the lower node
+type group struct {
+	from, to int
+	// jump is the areal winding left minus right of from to to per operand
+	jump [2]int
+	// line is true if a line of the operand runs along the group
+	line [2]bool
+	// left and right are the areal windings on either side per operand
+	left, right [2]int
+}
+
+// arrangement is the noded linework of two operands - the faces, edges and
+// nodes between them carry where they lie relative to each operand
+type arrangement struct {
+	parts    [2]*components
+	nodes    *nodeSet
+	groups   []*group
+	incident [][]int
+	// endpoints counts the line ends per node and operand
+	endpoints []([2]int)
+	// points marks the point components per node and operand
+	points []([2]bool)
+	rows   *slabIndex
+	cols   *slabIndex
+}
+
+func newArrangement(a, b *components) *arrangement {
+	arr := &arrangement{parts: [2]*components{a, b}}
+
+	var segments []segment
+	var points []Coord
+	for operand, c := range arr.parts {
+		segments = append(segments, c.segments(operand)...)
+		points = append(points, c.points...)
+	}
+
+	var edges []edge
+	arr.nodes, edges = node(segments, points, tolerance(a, b))
+
+	keys := make(map[[2]int]*group)
+	for _, e := range edges {
+		from, to, dir := e.from, e.to, 1
+		if from > to {
+			from, to, dir = to, from, -1
+		}
+		g, ok := keys[[2]int{from, to}]
+		if !ok {
+			g = &group{from: from, to: to}
+			keys[[2]int{from, to}] = g
+			arr.groups = append(arr.groups, g)
+		}
+		if e.line {
+			g.line[e.operand] = true
+		} else {
+			g.jump[e.operand] += dir
+		}
+	}
+
+	n := len(arr.nodes.coords)
+	arr.incident = make([][]int, n)
+	for i, g := range arr.groups {
+		arr.incident[g.from] = append(arr.incident[g.from], i)
+		arr.incident[g.to] = append(arr.incident[g.to], i)
+	}
+
+	arr.endpoints = make([][2]int, n)
+	arr.points = make([][2]bool, n)
+	for operand, c := range arr.parts {
+		for _, line := range c.lines {
+			if len(line) < 2 || samePoint(line[0], line[len(line)-1]) {
+				continue
+			}
+			arr.endpoints[arr.nodes.add(line[0])][operand]++
+			arr.endpoints[arr.nodes.add(line[len(line)-1])][operand]++
+		}
+		for _, p := range c.points {
+			arr.points[arr.nodes.add(p)][operand] = true
+		}
+	}
+
+	arr.windings()
+
+	return arr
+}
+
+// windings finds the areal winding of each operand on both sides of every
+// group - a ray cast off one group per connected part of the linework and
+// carried around the nodes from there
+func (arr *arrangement) windings() {
+	var areal []int
+	for i, g := range arr.groups {
+		if g.jump != [2]int{} {
+			areal = append(areal, i)
+		}
+	}
+	arr.rows = newSlabIndex(arr, areal, false)
+	arr.cols = newSlabIndex(arr, areal, true)
+
+	known := make([]bool, len(arr.groups))
+	visited := make([]bool, len(arr.nodes.coords))
+	for i := range arr.groups {
+		if known[i] {
+			continue
+		}
+		arr.castWinding(i)
+		known[i] = true
+
+		stack := []int{arr.groups[i].from, arr.groups[i].to}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if visited[n] {
+				continue
+			}
+			visited[n] = true
+
+			for _, j := range arr.aroundNode(n, known) {
+				g := arr.groups[j]
+				if !visited[g.from] {
+					stack = append(stack, g.from)
+				}
+				if !visited[g.to] {
+					stack = append(stack, g.to)
+				}
+			}
+		}
+	}
+}
+
+// castWinding finds the windings on both sides of a group by a ray off its
+// midpoint past the other groups
+func (arr *arrangement) castWinding(i int) {
+	g := arr.groups[i]
+	a, b := arr.nodes.coords[g.from], arr.nodes.coords[g.to]
+	mid := Coord{X: (a.X + b.X) / 2.0, Y: (a.Y + b.Y) / 2.0}
+
+	for operand := 0; operand < 2; operand++ {
+		if arr.parts[operand] == nil || len(arr.parts[operand].rings) == 0 {
+			continue
+		}
+		switch {
+		case a.Y != b.Y:
+			// The ray east leaves on the right of a group heading north
+			w := arr.winding(operand, mid, i, false)
+			if b.Y > a.Y {
+				g.right[operand], g.left[operand] = w, w+g.jump[operand]
+			} else {
+				g.left[operand], g.right[operand] = w, w-g.jump[operand]
+			}
+		default:
+			// The ray north leaves on the left of a group heading east
+			w := arr.winding(operand, mid, i, true)
+			if b.X > a.X {
+				g.left[operand], g.right[operand] = w, w-g.jump[operand]
+			} else {
+				g.right[operand], g.left[operand] = w, w+g.jump[operand]
+			}
+		}
+	}
+}
+
+// aroundNode carries the windings of a known group counterclockwise around
+// the node to the others - the face right of each group leaving the node is
+// the face left of the one before. Returns the incident groups
+func (arr *arrangement) aroundNode(n int, known []bool) []int {
+	at := arr.nodes.coords[n]
+	incident := append([]int(nil), arr.incident[n]...)
+	angle := func(i int) float64 {
+		g := arr.groups[i]
+		other := g.to
+		if other == n {
+			other = g.from
+		}
+		c := arr.nodes.coords[other]
+		return math.Atan2(c.Y-at.Y, c.X-at.X)
+	}
+	sort.Slice(incident, func(i, j int) bool { return angle(incident[i]) < angle(incident[j]) })
+
+	// Sides of a group seen leaving the node
+	sides := func(g *group) (left, right *[2]int) {
+		if g.from == n {
+			return &g.left, &g.right
+		}
+		return &g.right, &g.left
+	}
+
+	start := -1
+	for k, i := range incident {
+		if known[i] {
+			start = k
+			break
+		}
+	}
+	if start < 0 {
+		return incident
+	}
+
+	for step := 1; step < len(incident); step++ {
+		i := incident[(start+step)%len(incident)]
+		if known[i] {
+			continue
+		}
+		prevLeft, _ := sides(arr.groups[incident[(start+step-1)%len(incident)]])
+		left, right := sides(arr.groups[i])
+		*right = *prevLeft
+		for operand := range left {
+			jump := arr.groups[i].jump[operand]
+			if arr.groups[i].from != n {
+				jump = -jump
+			}
+			left[operand] = right[operand] + jump
+		}
+		known[i] = true
+	}
+	return incident
+}
+
+// winding is the areal winding number of the operand just east of p - or just
+// north when transposed - leaving out the group p lies on
+func (arr *arrangement) winding(operand int, p Coord, exclude int, transposed bool) int {
+	index := arr.rows
+	if transposed {
+		index = arr.cols
+	}
+
+	flip := func(c Coord) Coord {
+		if transposed {
+			return Coord{X: c.Y, Y: c.X}
+		}
+		return c
+	}
+
+	p = flip(p)
+	w := 0
+	for _, i := range index.query(p.Y) {
+		g := arr.groups[i]
+		if i == exclude || g.jump[operand] == 0 {
+			continue
+		}
+		a, b := flip(arr.nodes.coords[g.from]), flip(arr.nodes.coords[g.to])
+		switch {
+		case a.Y <= p.Y && b.Y > p.Y && orientation(a, b, p) > 0:
+			w += g.jump[operand]
+		case b.Y <= p.Y && a.Y > p.Y && orientation(a, b, p) < 0:
+			w -= g.jump[operand]
+		}
+	}
+
+	// Transposing mirrors the plane which flips the winding
+	if transposed {
+		return -w
+	}
+	return w
+}
+
+// nodeWinding is the areal winding of the operand around a node
+func (arr *arrangement) nodeWinding(operand, n int) int {
+	if len(arr.incident[n]) > 0 {
+		return arr.groups[arr.incident[n][0]].left[operand]
+	}
+	if arr.parts[operand] == nil || len(arr.parts[operand].rings) == 0 {
+		return 0
+	}
+	return arr.winding(operand, arr.nodes.coords[n], -1, false)
+}
+
+// groupLocation is where the edges of a group lie relative to the operand
+func (arr *arrangement) groupLocation(g *group, operand int) location {
+	left, right := inside(g.left[operand]), inside(g.right[operand])
+	switch {
+	case left != right:
+		return boundary
+	case left, g.line[operand]:
+		return interior
+	}
+	return exterior
+}
+
+// nodeLocation is where a node lies relative to the operand - line ends are
+// boundary points by the mod 2 rule
+func (arr *arrangement) nodeLocation(n, operand int) location {
+	online := false
+	for _, i := range arr.incident[n] {
+		g := arr.groups[i]
+		if inside(g.left[operand]) != inside(g.right[operand]) {
+			return boundary
+		}
+		online = online || g.line[operand]
+	}
+
+	switch {
+	case inside(arr.nodeWinding(operand, n)):
+		return interior
+	case arr.endpoints[n][operand]%2 == 1:
+		return boundary
+	case online, arr.points[n][operand]:
+		return interior
+	}
+	return exterior
+}
+
+// slabIndex buckets groups by the rows - or columns - they span for the
+// winding rays
+type slabIndex struct {
+	min, size float64
+	slabs     [][]int
+}
+
+func newSlabIndex(arr *arrangement, groups []int, columns bool) *slabIndex {
+	axis := func(c Coord) float64 {
+		if columns {
+			return c.X
+		}
+		return c.Y
+	}
+
+	index := &slabIndex{min: math.Inf(1)}
+	max := math.Inf(-1)
+	for _, i := range groups {
+		g := arr.groups[i]
+		for _, n := range []int{g.from, g.to} {
+			index.min = math.Min(index.min, axis(arr.nodes.coords[n]))
+			max = math.Max(max, axis(arr.nodes.coords[n]))
+		}
+	}
+
+	count := int(math.Sqrt(float64(len(groups)))) + 1
+	index.slabs = make([][]int, count)
+	index.size = (max - index.min) / float64(count)
+	if index.size <= 0 || math.IsNaN(index.size) {
+		index.size = 1
+	}
+
+	for _, i := range groups {
+		g := arr.groups[i]
+		lo, hi := axis(arr.nodes.coords[g.from]), axis(arr.nodes.coords[g.to])
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		for s := index.slab(lo); s <= index.slab(hi); s++ {
+			index.slabs[s] = append(index.slabs[s], i)
+		}
+	}
+
+	return index
+}
+
+func (s *slabIndex) slab(v float64) int {
+	i := int((v - s.min) / s.size)
+	if i < 0 {
+		return 0
+	}
+	if i >= len(s.slabs) {
+		return len(s.slabs) - 1
+	}
+	return i
+}
+
+func (s *slabIndex) query(v float64) []int {
+	if len(s.slabs) == 0 {
+		return nil
+	}
+	return s.slabs[s.slab(v)]
+}
+
+// overlayRule tells if a face inside or outside of each operand is kept
+type overlayRule func(a, b bool) bool
+
+var (
+	intersectionRule  overlayRule = func(a, b bool) bool { return a && b }
+	unionRule         overlayRule = func(a, b bool) bool { return a || b }
+	differenceRule    overlayRule = func(a, b bool) bool { return a && !b }
+	symDifferenceRule overlayRule = func(a, b bool) bool { return a != b }
+)
+
+// Intersection returns the points shared by the geometries - touching
+// boundaries of areas are kept as lines and points
+func (g *Geometry) Intersection(other *Geometry) (*Geometry, error) {
+	arr := newArrangement(g.components(), other.components())
+	return arr.intersection(), nil
+}
+
+// Union returns the area covered by either geometry - areal inputs only
+func (g *Geometry) Union(other *Geometry) (*Geometry, error) {
+	return g.arealOverlay("Union", other, unionRule)
+}
+
+// Difference returns the area of the geometry not covered by the other - areal
+// inputs only
+func (g *Geometry) Difference(other *Geometry) (*Geometry, error) {
+	return g.arealOverlay("Difference", other, differenceRule)
+}
+
+// SymDifference returns the area covered by exactly one geometry - areal
+// inputs only
+func (g *Geometry) SymDifference(other *Geometry) (*Geometry, error) {
+	return g.arealOverlay("SymDifference", other, symDifferenceRule)
+}
+
+// UnaryUnion merges the overlapping and adjacent areas of a geometry - areal
+// inputs only
+func (g *Geometry) UnaryUnion() (*Geometry, error) {
+	return g.arealOverlay("UnaryUnion", &Geometry{typ: POLYGON}, unionRule)
+}
+
+func (g *Geometry) arealOverlay(name string, other *Geometry, rule overlayRule) (*Geometry, error) {
+	a, b := g.components(), other.components()
+	for _, c := range []*components{a, b} {
+		if len(c.lines) > 0 || len(c.points) > 0 {
+			return nil, fmt.Errorf("%s: %w: only polygons are supported", name, errUnsupported)
+		}
+	}
+
+	arr := newArrangement(a, b)
+	return polygonal(arr.polygons(arr.areaEdges(rule))), nil
+}
+
+// areaEdges are the groups bounding the kept faces directed with the kept
+// face on their left
+func (arr *arrangement) areaEdges(rule overlayRule) [][2]int {
+	var edges [][2]int
+	for _, g := range arr.groups {
+		left := rule(inside(g.left[0]), inside(g.left[1]))
+		right := rule(inside(g.right[0]), inside(g.right[1]))
+		switch {
+		case left && !right:
+			edges = append(edges, [2]int{g.from, g.to})
+		case right && !left:
+			edges = append(edges, [2]int{g.to, g.from})
+		}
+	}
+	return edges
+}
+
+// intersection keeps the faces inside both operands, then the edges and
+// nodes in both that are not already part of the kept area
+func (arr *arrangement) intersection() *Geometry {
+	areaEdges := arr.areaEdges(intersectionRule)
+	polygons := arr.polygons(areaEdges)
+
+	used := make([]bool, len(arr.nodes.coords))
+	for _, e := range areaEdges {
+		used[e[0]], used[e[1]] = true, true
+	}
+
+	var lineEdges [][2]int
+	for _, g := range arr.groups {
+		left := intersectionRule(inside(g.left[0]), inside(g.left[1]))
+		right := intersectionRule(inside(g.right[0]), inside(g.right[1]))
+		if left || right {
+			continue
+		}
+		if arr.groupLocation(g, 0) != exterior && arr.groupLocation(g, 1) != exterior {
+			lineEdges = append(lineEdges, [2]int{g.from, g.to})
+			used[g.from], used[g.to] = true, true
+		}
+	}
+
+	var points []Coord
+	for n := range arr.nodes.coords {
+		if used[n] || arr.nodeLocation(n, 0) == exterior || arr.nodeLocation(n, 1) == exterior {
+			continue
+		}
+		// Nodes inside the kept area are covered already
+		if arr.nodeLocation(n, 0) == interior && inside(arr.nodeWinding(0, n)) &&
+			arr.nodeLocation(n, 1) == interior && inside(arr.nodeWinding(1, n)) {
+			continue
+		}
+		points = append(points, arr.nodes.coords[n])
+	}
+
+	lines := arr.lines(lineEdges)
+	if len(lines) == 0 && len(points) == 0 {
+		return polygonal(polygons)
+	}
+
+	var geoms []*Geometry
+	if len(polygons) > 0 {
+		area := polygonal(polygons)
+		if area.typ == MULTIPOLYGON {
+			geoms = append(geoms, area.parts...)
+		} else {
+			geoms = append(geoms, area)
+		}
+	}
+	for _, line := range lines {
+		geoms = append(geoms, &Geometry{typ: LINESTRING, coords: line})
+	}
+	for _, p := range points {
+		geoms = append(geoms, &Geometry{typ: POINT, coords: []Coord{p}})
+	}
+
+	if len(polygons) == 0 && len(points) == 0 {
+		if len(geoms) == 1 {
+			return geoms[0]
+		}
+		return &Geometry{typ: MULTILINESTRING, parts: geoms}
+	}
+	if len(polygons) == 0 && len(lines) == 0 {
+		if len(geoms) == 1 {
+			return geoms[0]
+		}
+		return &Geometry{typ: MULTIPOINT, parts: geoms}
+	}
+	return &Geometry{typ: GEOMETRYCOLLECTION, parts: geoms}
+}
+
+// polygons links directed edges into rings - shells wound clockwise and
+// holes counterclockwise like GEOS - and puts each hole in the smallest
+// shell around it
+func (arr *arrangement) polygons(edges [][2]int) [][][]Coord {
+	outgoing := make(map[int][]int)
+	for i, e := range edges {
+		outgoing[e[0]] = append(outgoing[e[0]], i)
+	}
+
+	used := make([]bool, len(edges))
+	var shells, holes [][]Coord
+	for start := range edges {
+		if used[start] {
+			continue
+		}
+
+		var ring []int
+		for e := start; !used[e]; {
+			used[e] = true
+			ring = append(ring, edges[e][0])
+			e = arr.nextEdge(edges, outgoing, e, used)
+			if e < 0 {
+				break
+			}
+		}
+
+		for _, loop := range splitLoops(ring) {
+			coords := make([]Coord, 0, len(loop)+1)
+			for _, n := range loop {
+				coords = append(coords, arr.nodes.coords[n])
+			}
+			coords = append(coords, coords[0])
+
+			switch area := signedArea(coords); {
+			case area > 0:
+				shells = append(shells, coords)
+			case area < 0:
+				holes = append(holes, coords)
+			}
+		}
+	}
+
+	sort.SliceStable(shells, func(i, j int) bool { return signedArea(shells[i]) < signedArea(shells[j]) })
+	polygons := make([][][]Coord, len(shells))
+	for i, shell := range shells {
+		polygons[i] = [][]Coord{reversed(shell)}
+	}
+
+	for _, hole := range holes {
+		probe := Coord{X: (hole[0].X + hole[1].X) / 2.0, Y: (hole[0].Y + hole[1].Y) / 2.0}
+		for i, shell := range shells {
+			if inRing(probe, shell) {
+				polygons[i] = append(polygons[i], reversed(hole))
+				break
+			}
+		}
+	}
+
+	return polygons
+}
+
+// nextEdge is the unused edge leaving the end of e that turns right the most
+// - keeps the kept face on the left and splits rings touching at a node
+func (arr *arrangement) nextEdge(edges [][2]int, outgoing map[int][]int, e int, used []bool) int {
+	from, at := arr.nodes.coords[edges[e][0]], arr.nodes.coords[edges[e][1]]
+	back := math.Atan2(from.Y-at.Y, from.X-at.X)
+
+	next, best := -1, math.Inf(1)
+	for _, candidate := range outgoing[edges[e][1]] {
+		if used[candidate] {
+			continue
+		}
+		to := arr.nodes.coords[edges[candidate][1]]
+		turn := math.Mod(back-math.Atan2(to.Y-at.Y, to.X-at.X)+4*math.Pi, 2*math.Pi)
+		if turn == 0 {
+			turn = 2 * math.Pi
+		}
+		if turn < best {
+			next, best = candidate, turn
+		}
+	}
+	return next
+}
+
+// splitLoops cuts a ring of nodes visiting a node twice into simple loops
+func splitLoops(ring []int) [][]int {
+	var loops [][]int
+	var stack []int
+	at := make(map[int]int)
+	for _, n := range ring {
+		if i, ok := at[n]; ok {
+			loop := append([]int(nil), stack[i:]...)
+			if len(loop) >= 3 {
+				loops = append(loops, loop)
+			}
+			for _, m := range stack[i+1:] {
+				delete(at, m)
+			}
+			stack = stack[:i+1]
+			continue
+		}
+		at[n] = len(stack)
+		stack = append(stack, n)
+	}
+	if len(stack) >= 3 {
+		loops = append(loops, stack)
+	}
+	return loops
+}
+
+// lines chains undirected edges into line strings through the nodes with
+// exactly two of them
+func (arr *arrangement) lines(edges [][2]int) [][]Coord {
+	adjacent := make(map[int][]int)
+	for i, e := range edges {
+		adjacent[e[0]] = append(adjacent[e[0]], i)
+		adjacent[e[1]] = append(adjacent[e[1]], i)
+	}
+
+	used := make([]bool, len(edges))
+	walk := func(start, e int) []Coord {
+		line := []Coord{arr.nodes.coords[start]}
+		at := start
+		for e >= 0 && !used[e] {
+			used[e] = true
+			if edges[e][0] == at {
+				at = edges[e][1]
+			} else {
+				at = edges[e][0]
+			}
+			line = append(line, arr.nodes.coords[at])
+
+			next := -1
+			if len(adjacent[at]) == 2 {
+				for _, candidate := range adjacent[at] {
+					if !used[candidate] {
+						next = candidate
+					}
+				}
+			}
+			e = next
+		}
+		return line
+	}
+
+	ends := make([]int, 0, len(adjacent))
+	for n, incident := range adjacent {
+		if len(incident) != 2 {
+			ends = append(ends, n)
+		}
+	}
+	sort.Ints(ends)
+
+	var lines [][]Coord
+	// Open chains start at their ends - the rest are closed loops
+	for _, n := range ends {
+		for _, e := range adjacent[n] {
+			if !used[e] {
+				lines = append(lines, walk(n, e))
+			}
+		}
+	}
+	for e := range edges {
+		if !used[e] {
+			lines = append(lines, walk(edges[e][0], e))
+		}
+	}
+
+	return lines
+}
+
+// inRing is true if c lies inside the closed ring by the even odd rule
+func inRing(c Coord, ring []Coord) bool {
+	inside := false
+	for i := 0; i+1 < len(ring); i++ {
+		a, b := ring[i], ring[i+1]
+		if (a.Y > c.Y) != (b.Y > c.Y) && c.X < a.X+(c.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// inside is true for a winding of a face inside the areas - shells wind
+// counterclockwise so lobes of self-crossing rings wound the other way and
+// holes sticking out of their shell are outside like a GEOS zero buffer
+func inside(winding int) bool {
+	return winding > 0
+}
@@ -0,0 +1,403 @@
+//go:build purego
+// +build purego
+
+package geom
+
+import (
+	"fmt"
+)
+
+// matrix is a DE-9IM intersection matrix of the dimension each location pair
+// shares - -1 when they share nothing
+type matrix [3][3]int
+
+func (m *matrix) set(a, b location, dimension int) {
+	if dimension > m[a][b] {
+		m[a][b] = dimension
+	}
+}
+
+func (m matrix) String() string {
+	b := make([]byte, 0, 9)
+	for _, row := range m {
+		for _, d := range row {
+			if d < 0 {
+				b = append(b, 'F')
+			} else {
+				b = append(b, byte('0'+d))
+			}
+		}
+	}
+	return string(b)
+}
+
+// matches is true if the matrix fits the pattern of T, F, *, 0, 1 and 2
+func (m matrix) matches(pattern string) (bool, error) {
+	if len(pattern) != 9 {
+		return false, fmt.Errorf("RelatePat: invalid pattern %q - must be 9 characters", pattern)
+	}
+	for i := 0; i < 9; i++ {
+		d := m[i/3][i%3]
+		switch c := pattern[i]; c {
+		case '*':
+		case 'T', 't':
+			if d < 0 {
+				return false, nil
+			}
+		case 'F', 'f':
+			if d >= 0 {
+				return false, nil
+			}
+		case '0', '1', '2':
+			if d != int(c-'0') {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("RelatePat: invalid pattern %q - unknown character %q", pattern, c)
+		}
+	}
+	return true, nil
+}
+
+// relate builds the intersection matrix from the faces, edges and nodes of the
+// arrangement of both geometries
+func relate(a, b *components) matrix {
+	var m matrix
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] = -1
+		}
+	}
+	m[exterior][exterior] = 2
+
+	arr := newArrangement(a, b)
+	for _, g := range arr.groups {
+		m.set(arr.groupLocation(g, 0), arr.groupLocation(g, 1), 1)
+		for _, side := range [][2]int{g.left, g.right} {
+			m.set(faceLocation(side[0]), faceLocation(side[1]), 2)
+		}
+	}
+	for n := range arr.nodes.coords {
+		m.set(arr.nodeLocation(n, 0), arr.nodeLocation(n, 1), 0)
+	}
+
+	return m
+}
+
+func faceLocation(winding int) location {
+	if inside(winding) {
+		return interior
+	}
+	return exterior
+}
+
+// Relate returns the DE-9IM intersection matrix of the geometries
+func (g *Geometry) Relate(other *Geometry) (string, error) {
+	return relate(g.components(), other.components()).String(), nil
+}
+
+// RelatePat returns true if the intersection matrix of the geometries matches
+// the pattern
+func (g *Geometry) RelatePat(other *Geometry, pattern string) (bool, error) {
+	return relate(g.components(), other.components()).matches(pattern)
+}
+
+// Disjoint returns true if the geometries share no point
+func (g *Geometry) Disjoint(other *Geometry) (bool, error) {
+	return !sharePoint(g.components(), other.components()), nil
+}
+
+// Intersects returns true if the geometries share a point
+func (g *Geometry) Intersects(other *Geometry) (bool, error) {
+	return sharePoint(g.components(), other.components()), nil
+}
+
+// Touches returns true if the geometries share boundary points only
+func (g *Geometry) Touches(other *Geometry) (bool, error) {
+	return predicate(g.components(), other.components(), touches), nil
+}
+
+// Crosses returns true if the geometries share interior points of a lower
+// dimension than the higher of the two
+func (g *Geometry) Crosses(other *Geometry) (bool, error) {
+	return predicate(g.components(), other.components(), crosses), nil
+}
+
+// Within returns true if the geometry lies in the interior of the other
+func (g *Geometry) Within(other *Geometry) (bool, error) {
+	return predicate(g.components(), other.components(), within), nil
+}
+
+// Contains returns true if the other geometry lies in the interior of this one
+func (g *Geometry) Contains(other *Geometry) (bool, error) {
+	return predicate(g.components(), other.components(), contains), nil
+}
+
+// Overlaps returns true if the geometries of the same dimension share some
+// but not all interior points
+func (g *Geometry) Overlaps(other *Geometry) (bool, error) {
+	return predicate(g.components(), other.components(), overlaps), nil
+}
+
+// Equals returns true if the geometries cover the same points
+func (g *Geometry) Equals(other *Geometry) (bool, error) {
+	return predicate(g.components(), other.components(), equals), nil
+}
+
+// Covers returns true if no point of the other geometry lies outside this one
+func (g *Geometry) Covers(other *Geometry) (bool, error) {
+	return predicate(g.components(), other.components(), covers), nil
+}
+
+// CoveredBy returns true if no point of the geometry lies outside the other
+func (g *Geometry) CoveredBy(other *Geometry) (bool, error) {
+	return predicate(other.components(), g.components(), covers), nil
+}
+
+// predicateRule decides a named predicate from the matrix and dimensions
+type predicateRule func(m matrix, dimA, dimB int) bool
+
+// predicate fails without a shared point - so empty geometries are only
+// disjoint like GEOS. Geometries sharing no point skip the arrangement
+func predicate(a, b *components, rule predicateRule) bool {
+	if !sharePoint(a, b) {
+		return rule(apart(a, b), a.dimension(), b.dimension())
+	}
+	return rule(relate(a, b), a.dimension(), b.dimension())
+}
+
+// apart is the matrix of geometries sharing no point - their interiors only
+// meet the exterior of the other. Boundaries are left out as every predicate
+// already fails on the interiors and boundaries not meeting
+func apart(a, b *components) matrix {
+	var m matrix
+	for i := range m {
+		for j := range m[i] {
+			m[i][j] = -1
+		}
+	}
+	m[exterior][exterior] = 2
+	m[interior][exterior] = a.dimension()
+	m[exterior][interior] = b.dimension()
+	return m
+}
+
+// sharePoint is true if the geometries share a point within the snap
+// tolerance - stops at the first edge of one meeting an edge of the other
+// found through an STR tree, then checks the points and whether one lies
+// inside the other. Areas are taken as valid like the GEOS predicates
+func sharePoint(a, b *components) bool {
+	if a.dimension() < 0 || b.dimension() < 0 {
+		return false
+	}
+	tol := tolerance(a, b)
+	if !a.bounds().expand(tol).overlaps(b.bounds()) {
+		return false
+	}
+
+	ea, eb := newEdgeIndex(a), newEdgeIndex(b)
+	for _, s := range ea.segments {
+		if eb.near(s, tol) {
+			return true
+		}
+	}
+	for _, p := range a.points {
+		if eb.near(segment{a: p, b: p}, tol) || locateInRings(p, b.rings) != exterior {
+			return true
+		}
+	}
+	for _, p := range b.points {
+		if ea.near(segment{a: p, b: p}, tol) || locateInRings(p, a.rings) != exterior {
+			return true
+		}
+	}
+
+	// With no edges meeting each ring or line lies wholly inside or outside
+	// the areas of the other
+	for _, parts := range [][][]Coord{a.rings, a.lines} {
+		for _, part := range parts {
+			if len(part) > 0 && locateInRings(part[0], b.rings) != exterior {
+				return true
+			}
+		}
+	}
+	for _, parts := range [][][]Coord{b.rings, b.lines} {
+		for _, part := range parts {
+			if len(part) > 0 && locateInRings(part[0], a.rings) != exterior {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// edgeIndex is an STR tree of the edges and points of a geometry - points
+// are indexed as zero length edges
+type edgeIndex struct {
+	segments []segment
+	tree     *strTree
+}
+
+func newEdgeIndex(c *components) *edgeIndex {
+	segments := c.segments(0)
+	for _, p := range c.points {
+		segments = append(segments, segment{a: p, b: p})
+	}
+	boxes := make([]box, len(segments))
+	for i, s := range segments {
+		boxes[i] = s.box()
+	}
+	return &edgeIndex{segments: segments, tree: newSTRTree(boxes)}
+}
+
+// near is true if an indexed edge meets the segment or passes within the
+// tolerance of one of its ends
+func (e *edgeIndex) near(s segment, tol float64) bool {
+	return !e.tree.query(s.box().expand(tol), func(i int) bool {
+		t := e.segments[i]
+		if len(segmentIntersection(s.a, s.b, t.a, t.b)) > 0 {
+			return false
+		}
+		return segmentDistance(s.a, t.a, t.b) > tol && segmentDistance(s.b, t.a, t.b) > tol &&
+			segmentDistance(t.a, s.a, s.b) > tol && segmentDistance(t.b, s.a, s.b) > tol
+	})
+}
+
+func pattern(m matrix, p string) bool {
+	ok, _ := m.matches(p)
+	return ok
+}
+
+func touches(m matrix, _, _ int) bool {
+	return pattern(m, "FT*******") || pattern(m, "F**T*****") || pattern(m, "F***T****")
+}
+
+func crosses(m matrix, dimA, dimB int) bool {
+	switch {
+	case dimA == 1 && dimB == 1:
+		return pattern(m, "0********")
+	case dimA < dimB:
+		return pattern(m, "T*T******")
+	case dimA > dimB:
+		return pattern(m, "T*****T**")
+	}
+	return false
+}
+
+func within(m matrix, _, _ int) bool {
+	return pattern(m, "T*F**F***")
+}
+
+func contains(m matrix, _, _ int) bool {
+	return pattern(m, "T*****FF*")
+}
+
+func overlaps(m matrix, dimA, dimB int) bool {
+	switch {
+	case dimA != dimB:
+		return false
+	case dimA == 1:
+		return pattern(m, "1*T***T**")
+	}
+	return pattern(m, "T*T***T**")
+}
+
+func equals(m matrix, _, _ int) bool {
+	return pattern(m, "T*F**FFF*")
+}
+
+func covers(m matrix, _, _ int) bool {
+	return pattern(m, "T*****FF*") || pattern(m, "*T****FF*") || pattern(m, "***T**FF*") || pattern(m, "****T*FF*")
+}
+
+// PGeometry is a geometry prepared for repeated predicates - point queries
+// against areas skip building the arrangement
+type PGeometry struct {
+	g     *Geometry
+	parts *components
+}
+
+// Contains returns true if the other geometry lies in the interior of the
+// prepared one
+func (p *PGeometry) Contains(other *Geometry) (bool, error) {
+	return p.predicate(other, contains, func(loc location) bool { return loc == interior })
+}
+
+// Covers returns true if no point of the other geometry lies outside the
+// prepared one
+func (p *PGeometry) Covers(other *Geometry) (bool, error) {
+	return p.predicate(other, covers, func(loc location) bool { return loc != exterior })
+}
+
+// Intersects returns true if the geometries share a point
+func (p *PGeometry) Intersects(other *Geometry) (bool, error) {
+	return sharePoint(p.parts, other.components()), nil
+}
+
+// Disjoint returns true if the geometries share no point
+func (p *PGeometry) Disjoint(other *Geometry) (bool, error) {
+	return !sharePoint(p.parts, other.components()), nil
+}
+
+// Within returns true if the prepared geometry lies in the interior of the
+// other
+func (p *PGeometry) Within(other *Geometry) (bool, error) {
+	return p.g.Within(other)
+}
+
+// CoveredBy returns true if no point of the prepared geometry lies outside the
+// other
+func (p *PGeometry) CoveredBy(other *Geometry) (bool, error) {
+	return p.g.CoveredBy(other)
+}
+
+// Crosses returns true if the geometries share interior points of a lower
+// dimension than the higher of the two
+func (p *PGeometry) Crosses(other *Geometry) (bool, error) {
+	return p.g.Crosses(other)
+}
+
+// Overlaps returns true if the geometries of the same dimension share some
+// but not all interior points
+func (p *PGeometry) Overlaps(other *Geometry) (bool, error) {
+	return p.g.Overlaps(other)
+}
+
+// Touches returns true if the geometries share boundary points only
+func (p *PGeometry) Touches(other *Geometry) (bool, error) {
+	return p.g.Touches(other)
+}
+
+// predicate answers a point against a purely areal geometry by locating it -
+// anything else goes through the full matrix
+func (p *PGeometry) predicate(other *Geometry, rule predicateRule, located func(location) bool) (bool, error) {
+	b := other.components()
+	if len(b.points) == 1 && len(b.lines) == 0 && len(b.rings) == 0 &&
+		len(p.parts.lines) == 0 && len(p.parts.points) == 0 && len(p.parts.rings) > 0 {
+		return located(locateInRings(b.points[0], p.parts.rings)), nil
+	}
+	return predicate(p.parts, b, rule), nil
+}
+
+// locateInRings finds a point against oriented areal rings by their winding
+func locateInRings(c Coord, rings [][]Coord) location {
+	w := 0
+	for _, ring := range rings {
+		for i := 0; i+1 < len(ring); i++ {
+			a, b := ring[i], ring[i+1]
+			if (c.X == a.X && c.Y == a.Y) || (orientation(a, b, c) == 0 && inBox(c, a, b)) {
+				return boundary
+			}
+			switch {
+			case a.Y <= c.Y && b.Y > c.Y && orientation(a, b, c) > 0:
+				w++
+			case b.Y <= c.Y && a.Y > c.Y && orientation(a, b, c) < 0:
+				w--
+			}
+		}
+	}
+	if inside(w) {
+		return interior
+	}
+	return exterior
+}
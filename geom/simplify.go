@@ -0,0 +1,222 @@
+//go:build purego
+// +build purego
+
+package geom
+
+// Simplify returns the geometry simplified with Douglas-Peucker - areas are
+// rebuilt valid where the simplified rings cross
+func (g *Geometry) Simplify(tolerance float64) (*Geometry, error) {
+	s := &simplifier{tolerance: tolerance}
+	simplified := s.transform(g)
+	if g.areal() {
+		return simplified.BufferWithOpts(0, BufferOpts{})
+	}
+	return simplified, nil
+}
+
+// SimplifyP returns the geometry simplified with Douglas-Peucker without
+// letting any line or ring cross another or move to its other side - rings
+// keep at least four points
+func (g *Geometry) SimplifyP(tolerance float64) (*Geometry, error) {
+	s := &simplifier{tolerance: tolerance, preserve: true}
+	s.collect(g)
+	return s.transform(g), nil
+}
+
+// simplifier runs Douglas-Peucker over the lines and rings of a geometry -
+// checking flattened sections against every other current segment when the
+// topology is preserved
+type simplifier struct {
+	tolerance float64
+	preserve  bool
+	paths     [][]Coord
+	keep      [][]bool
+	index     map[*Geometry]int
+}
+
+// collect registers every line and ring up front so sections are checked
+// against the linework not simplified yet
+func (s *simplifier) collect(g *Geometry) {
+	if s.index == nil {
+		s.index = make(map[*Geometry]int)
+	}
+	switch g.typ {
+	case LINESTRING, LINEARRING:
+		s.index[g] = len(s.paths)
+		s.paths = append(s.paths, g.coords)
+		keep := make([]bool, len(g.coords))
+		for i := range keep {
+			keep[i] = true
+		}
+		s.keep = append(s.keep, keep)
+	case POLYGON:
+		for _, ring := range g.rings {
+			s.collect(ring)
+		}
+	default:
+		for _, part := range g.parts {
+			s.collect(part)
+		}
+	}
+}
+
+func (s *simplifier) transform(g *Geometry) *Geometry {
+	switch g.typ {
+	case LINESTRING:
+		coords := s.simplify(g)
+		if len(coords) < 2 {
+			return &Geometry{typ: LINESTRING}
+		}
+		return &Geometry{typ: LINESTRING, coords: coords}
+	case LINEARRING:
+		coords := s.simplify(g)
+		if len(coords) < 4 {
+			return &Geometry{typ: LINEARRING}
+		}
+		return &Geometry{typ: LINEARRING, coords: coords}
+	case POLYGON:
+		if g.empty() {
+			return g
+		}
+		shell := s.transform(g.rings[0])
+		if shell.empty() {
+			return &Geometry{typ: POLYGON}
+		}
+		polygon := &Geometry{typ: POLYGON, rings: []*Geometry{shell}}
+		for _, hole := range g.rings[1:] {
+			if ring := s.transform(hole); !ring.empty() {
+				polygon.rings = append(polygon.rings, ring)
+			}
+		}
+		return polygon
+	case POINT:
+		return g
+	default:
+		collection := &Geometry{typ: g.typ}
+		for _, part := range g.parts {
+			if simplified := s.transform(part); !simplified.empty() {
+				collection.parts = append(collection.parts, simplified)
+			}
+		}
+		return collection
+	}
+}
+
+// simplify returns the kept coordinates of a line or ring
+func (s *simplifier) simplify(g *Geometry) []Coord {
+	if len(g.coords) < 3 {
+		return copyCoords(g.coords)
+	}
+
+	path := g.coords
+	keep := make([]bool, len(path))
+	if i, ok := s.index[g]; ok {
+		keep = s.keep[i]
+	} else {
+		for i := range keep {
+			keep[i] = true
+		}
+	}
+	ring := g.typ == LINEARRING
+
+	var section func(i, j int)
+	section = func(i, j int) {
+		if j-i < 2 {
+			return
+		}
+
+		// Farthest point from the chord - from the point for a whole ring
+		far, farthest := i+1, -1.0
+		for k := i + 1; k < j; k++ {
+			if d := segmentDistance(path[k], path[i], path[j]); d > farthest {
+				far, farthest = k, d
+			}
+		}
+
+		whole := ring && i == 0 && j == len(path)-1
+		if farthest <= s.tolerance && !whole && s.flattenable(g, keep, i, j, ring) {
+			for k := i + 1; k < j; k++ {
+				keep[k] = false
+			}
+			return
+		}
+
+		section(i, far)
+		section(far, j)
+	}
+	section(0, len(path)-1)
+
+	var coords []Coord
+	for i, c := range path {
+		if keep[i] {
+			coords = append(coords, c)
+		}
+	}
+	return coords
+}
+
+// flattenable is true if replacing the section by its chord keeps a ring at
+// four points and - when preserving topology - crosses no other segment
+func (s *simplifier) flattenable(g *Geometry, keep []bool, i, j int, ring bool) bool {
+	if ring {
+		kept := 0
+		for k, ok := range keep {
+			if ok && (k <= i || k >= j) {
+				kept++
+			}
+		}
+		if kept < 4 {
+			return false
+		}
+	}
+	if !s.preserve {
+		return true
+	}
+
+	a, b := g.coords[i], g.coords[j]
+	self := s.index[g]
+	for p, path := range s.paths {
+		prev := -1
+		for k := range path {
+			if !s.keep[p][k] || (p == self && k > i && k < j) {
+				continue
+			}
+			if prev >= 0 && !(p == self && prev == i && k == j) && crossesChord(a, b, path[prev], path[k]) {
+				return false
+			}
+			prev = k
+		}
+	}
+
+	// Without crossings other linework only switches sides of the section
+	// when it lies between the section and its chord
+	between := append(copyCoords(g.coords[i:j+1]), a)
+	if signedArea(between) < 0 {
+		between = reversed(between)
+	}
+	for p, path := range s.paths {
+		for k, c := range path {
+			if s.keep[p][k] && !(p == self && k >= i && k <= j) && locateInRings(c, [][]Coord{between}) == interior {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// crossesChord is true if the chord a to b meets the segment anywhere but a
+// shared end
+func crossesChord(a, b, p, q Coord) bool {
+	for _, c := range segmentIntersection(a, b, p, q) {
+		shared := (samePoint(c, a) || samePoint(c, b)) && (samePoint(c, p) || samePoint(c, q))
+		if !shared {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Geometry) areal() bool {
+	c := g.components()
+	return len(c.rings) > 0 && len(c.lines) == 0 && len(c.points) == 0
+}
@@ -0,0 +1,137 @@
+package gogeospace
+
+import (
+	"fmt"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+// getGeosGeometry builds the GEOS geometry of any geometry type - single
+// rings are repaired first when the options ask for it
+func getGeosGeometry(geometry point.Geometry, f frame, opts Options) (*geom.Geometry, error) {
+	switch g := geometry.(type) {
+	case *point.Point:
+		if g == nil {
+			return nil, &PolygonError{Reason: "nil point", Err: ErrInvalidPolygon}
+		}
+		if !validCoordinate(g) {
+			return nil, &PolygonError{Coordinates: []*point.Point{g}, Reason: ValidationIssue{Reason: InvalidCoordinate, Location: g}.String(), Err: ErrInvalidPolygon}
+		}
+		return geom.NewPoint(f.coord(g))
+	case point.LineString:
+		for _, p := range g {
+			if p == nil {
+				return nil, &PolygonError{Coordinates: g, Reason: "nil point", Err: ErrInvalidPolygon}
+			}
+			if !validCoordinate(p) {
+				return nil, &PolygonError{Coordinates: g, Reason: ValidationIssue{Reason: InvalidCoordinate, Location: p}.String(), Err: ErrInvalidPolygon}
+			}
+		}
+		if countDistinctPoints(g) < 2 {
+			return nil, &PolygonError{Coordinates: g, Reason: "fewer than two distinct points", Err: ErrTooFewPoints}
+		}
+		coords := f.coords(f.densifyPoints(g, false), f.lng)
+		if f.fits(coords) {
+			return geom.NewLineString(coords...)
+		}
+		return f.fold(geom.LINESTRING, func(shift float64) (*geom.Geometry, error) {
+			return geom.NewLineString(shiftCoords(coords, shift)...)
+		})
+	case point.Ring:
+		return getGeosPolygon(g, f, opts)
+	case *point.Polygon:
+		if opts.Repair {
+			return getGeosRepairedGeometry(g, f)
+		}
+		return getGeosPolygonFromPolygon(g, f)
+	case point.MultiPolygon:
+		if opts.Repair {
+			return getGeosRepairedGeometry(g, f)
+		}
+		return getGeosGeometryFromMultiPolygon(g, f)
+	case *point.GeometryCollection:
+		return getGeosGeometryFromCollection(g, f, opts)
+	default:
+		return nil, &PolygonError{Reason: fmt.Sprintf("unsupported geometry %T", geometry), Err: ErrInvalidPolygon}
+	}
+}
+
+// getGeosGeometryFromCollection builds a GEOS geometry collection of every
+// polygon, line and point
+func getGeosGeometryFromCollection(collection *point.GeometryCollection, f frame, opts Options) (*geom.Geometry, error) {
+	if collection == nil {
+		return nil, &PolygonError{Reason: "nil geometry collection", Err: ErrInvalidPolygon}
+	}
+
+	var geoms []*geom.Geometry
+	for _, polygon := range collection.Polygons {
+		geo, err := getGeosPolygonFromPolygon(polygon, f)
+		if err != nil {
+			return nil, err
+		}
+		geoms = append(geoms, geo)
+	}
+	for _, line := range collection.LineStrings {
+		geo, err := getGeosGeometry(line, f, opts)
+		if err != nil {
+			return nil, err
+		}
+		geoms = append(geoms, geo)
+	}
+	for _, p := range collection.Points {
+		geo, err := getGeosGeometry(p, f, opts)
+		if err != nil {
+			return nil, err
+		}
+		geoms = append(geoms, geo)
+	}
+
+	return geom.NewCollection(geom.GEOMETRYCOLLECTION, geoms...)
+}
+
+// newFrameForGeometry creates the plane selected by the options centered on
+// all the passed in geometries
+func newFrameForGeometry(opts Options, geometries ...point.Geometry) (frame, error) {
+	var points [][]*point.Point
+	for _, geometry := range geometries {
+		points = append(points, pointsOf(geometry)...)
+	}
+
+	return newFrameForPoints(opts, points...)
+}
+
+// pointsOf returns the outer points of a geometry - holes lie within their
+// exterior so they are skipped
+func pointsOf(geometry point.Geometry) [][]*point.Point {
+	switch g := geometry.(type) {
+	case *point.Point:
+		return [][]*point.Point{{g}}
+	case point.LineString:
+		return [][]*point.Point{g}
+	case point.Ring:
+		return [][]*point.Point{g}
+	case *point.Polygon:
+		if g == nil {
+			return nil
+		}
+		return [][]*point.Point{g.Exterior}
+	case point.MultiPolygon:
+		var points [][]*point.Point
+		for _, polygon := range g {
+			points = append(points, pointsOf(polygon)...)
+		}
+		return points
+	case *point.GeometryCollection:
+		if g == nil {
+			return nil
+		}
+		points := pointsOf(g.Polygons)
+		for _, line := range g.LineStrings {
+			points = append(points, line)
+		}
+		return append(points, g.Points)
+	default:
+		return nil
+	}
+}
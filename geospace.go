@@ -1,304 +1,527 @@
 package gogeospace
 
 import (
+	"context"
 	"fmt"
-	"log"
-	"runtime/debug"
-	"strconv"
-	"strings"
+	"math"
 
-	"github.com/jdejesus007/gogeos/geos"
+	"github.com/jdejesus007/gogeospace/disc"
+	"github.com/jdejesus007/gogeospace/geom"
 	"github.com/jdejesus007/gogeospace/haversine"
 	"github.com/jdejesus007/gogeospace/point"
 	"github.com/jdejesus007/gogeospace/vincenty"
-	"github.com/pkg/errors"
 )
 
 // DoPolygonsIntersect takes two arrays of coordinates and return true/false and
-// error if polygons intersect
-func DoPolygonsIntersect(coordinatesA, coordinatesB []*point.Point) (intersects bool, err error) {
-	// Catch internal C library panics
-	defer func() {
-		if e := recover(); e != nil {
-			var ok bool
-			intersects = false
-			err, ok = e.(error)
-			if !ok {
-				err = errors.Wrap(fmt.Errorf("Error: %v", e), fmt.Sprintf("Debug Stack: %s", string(debug.Stack())))
-				return
-			}
-			err = errors.Wrap(err, fmt.Sprintf("Debug Stack: %s", string(debug.Stack())))
-		}
-	}()
-
-	dotPolygonA, err := getGeosPolygonFromCoordinates(coordinatesA)
-	if err != nil {
-		return false, err
-	}
+// error if polygons intersect - optional options select the projection the
+// polygons are compared in and whether they are repaired first. Answered with
+// the intersects predicate without computing the intersection geometry
+func DoPolygonsIntersect(coordinatesA, coordinatesB []*point.Point, opts ...Options) (intersects bool, err error) {
+	return DoPolygonsIntersectContext(context.Background(), coordinatesA, coordinatesB, opts...)
+}
 
-	if dotPolygonA == nil {
-		return false,
-			fmt.Errorf("nil polygon from A coordinates - incoming: %v", coordinatesA)
-	}
+// DoPolygonsIntersectContext is DoPolygonsIntersect giving up with ctx.Err()
+// between geometry building and the predicate once the context is done
+func DoPolygonsIntersectContext(ctx context.Context, coordinatesA, coordinatesB []*point.Point, opts ...Options) (intersects bool, err error) {
+	return predicateContext(ctx, "intersects", (*geom.Geometry).Intersects, point.Ring(coordinatesA), point.Ring(coordinatesB), getOptions(opts))
+}
 
-	dotPolygonB, err := getGeosPolygonFromCoordinates(coordinatesB)
-	if err != nil {
-		return false, err
-	}
+// GetIntersectedPolygonByPolygonAndCenterPointRadiusHaveriseDisc returns the
+// intersection of an individual polygon and a disc derived of the passed in
+// center point and radius with haversine algorithm - one polygon per disjoint part,
+// each with its exterior ring and interior rings (holes) - lines and points left
+// where the disc only touches the polygon are dropped
+// Params:
+// Polygonal ring, polygon with holes or multi polygon of lat,lng in degrees
+// Lat center point lat in degrees
+// Lng center point lng in degrees
+// Radius off center point to create spherical disc or circle in meters
+// Opts optional settings such as the projection centered on the disc
+func GetIntersectedPolygonByPolygonAndCenterPointRadiusHaveriseDisc(
+	polygonal point.Polygonal,
+	lat float32,
+	lng float32,
+	radius float64,
+	opts ...Options) (multiPolygon point.MultiPolygon, err error) {
 
-	if dotPolygonB == nil {
-		return false,
-			fmt.Errorf("nil polygon from B coordinates - incoming: %v", coordinatesB)
-	}
+	return GetIntersectedPolygonByPolygonAndCenterPointRadiusHaveriseDiscContext(context.Background(), polygonal, lat, lng, radius, opts...)
+}
 
-	polyAB, err := dotPolygonA.Intersection(dotPolygonB)
-	if err != nil {
-		return false, err
-	}
+// GetIntersectedPolygonByPolygonAndCenterPointRadiusHaveriseDiscContext is GetIntersectedPolygonByPolygonAndCenterPointRadiusHaveriseDisc giving up with ctx.Err() between disc generation,
+// geometry building and the intersection once the context is done
+func GetIntersectedPolygonByPolygonAndCenterPointRadiusHaveriseDiscContext(
+	ctx context.Context,
+	polygonal point.Polygonal,
+	lat float32,
+	lng float32,
+	radius float64,
+	opts ...Options) (multiPolygon point.MultiPolygon, err error) {
 
-	if polyAB == nil {
-		return false,
-			fmt.Errorf("nil polygon from A/B intersection - incoming A/B: [%v - %v]", coordinatesA, coordinatesB)
-	}
+	options := getOptions(opts)
+	options.Generator = haversine.Generator{}
 
-	intersectedPoly := geos.Must(polyAB, nil)
+	options.AreaOnly = true
 
-	// If nonintersecting - return empty to skip area
-	if intersectedPoly.String()[len(intersectedPoly.String())-5:] == "EMPTY" {
-		return false, nil
+	collection, err := IntersectPolygonWithDiscContext(ctx, polygonal, &point.Point{Lat: float64(lat), Lng: float64(lng)}, radius, options)
+	if err != nil {
+		return nil, err
 	}
 
-	return true, nil
+	return collection.Polygons, nil
 }
 
-// GetIntersectedPolygonByPolygonAndCenterPointRadiusHaveriseDisc returns one polygon consisting of
-// an individual intersected polygon and a disc derived of the passed in center
-// point and radius with haversine algorithm
+// GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDisc returns the
+// intersection of an individual polygon and a disc derived of the passed in
+// center point and radius with vincenty algorithm - one polygon per disjoint part,
+// each with its exterior ring and interior rings (holes) - lines and points left
+// where the disc only touches the polygon are dropped
 // Params:
-// Coordinates forming a polygon slice of lat,lng in degress
+// Polygonal ring, polygon with holes or multi polygon of lat,lng in degrees
 // Lat center point lat in degrees
 // Lng center point lng in degrees
 // Radius off center point to create spherical disc or circle in meters
-func GetIntersectedPolygonByPolygonAndCenterPointRadiusHaveriseDisc(
-	polyCoords []*point.Point,
+// Opts optional settings such as the projection centered on the disc
+func GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDisc(
+	polygonal point.Polygonal,
 	lat float32,
 	lng float32,
-	radius float64) (coordinates []*point.Point, err error) {
-
-	// Catch internal C library panics
-	defer func() {
-		if e := recover(); e != nil {
-			var ok bool
-			err, ok = e.(error)
-			if !ok {
-				err = errors.Wrap(fmt.Errorf("Error: %v", e), fmt.Sprintf("Debug Stack: %s", string(debug.Stack())))
-				return
-			}
-			err = errors.Wrap(err, fmt.Sprintf("Debug Stack: %s", string(debug.Stack())))
-		}
-	}()
+	radius float64,
+	opts ...Options) (multiPolygon point.MultiPolygon, err error) {
+
+	return GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDiscContext(context.Background(), polygonal, lat, lng, radius, opts...)
+}
+
+// GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDiscContext is GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDisc giving up with ctx.Err() between disc generation,
+// geometry building and the intersection once the context is done
+func GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDiscContext(
+	ctx context.Context,
+	polygonal point.Polygonal,
+	lat float32,
+	lng float32,
+	radius float64,
+	opts ...Options) (multiPolygon point.MultiPolygon, err error) {
 
-	dotPolygon, err := getGeosPolygonFromCoordinates(polyCoords)
+	options := getOptions(opts)
+	options.Generator = vincenty.Generator{} // accurate to within 0.5 mm distance or 0.000015″ of bearing
+
+	options.AreaOnly = true
+
+	collection, err := IntersectPolygonWithDiscContext(ctx, polygonal, &point.Point{Lat: float64(lat), Lng: float64(lng)}, radius, options)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert to spherical radius -> radians = distance / earth radius
-	// C lib has problems with gaps around polygon edges
-	polyCoordinates := haversine.CreateDisc(float64(lat), float64(lng), radius)
+	return collection.Polygons, nil
+}
 
-	if dotPolygon == nil {
-		return nil,
-			fmt.Errorf("nil geometric poly shape from incoming boundary coordinates - [%v]",
-				coordinates)
+func processPolyCoordinates(ctx context.Context, polyCoordinates []*point.Point, dotPolygon *geom.Geometry, f frame, opts Options) (*point.GeometryCollection, error) {
+	// Final intersected polygon - do this for DOT with service radius only
+	circlePoly, err := getGeosPolygonFromCoordinates(polyCoordinates, f)
+	if err != nil {
+		return nil, err
 	}
 
-	if polyCoordinates == nil {
-		return nil,
-			fmt.Errorf("nil haversine disc - incoming lat/lng/radius - [%f / %f / %f]",
-				lat, lng, radius)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	intersectedPolyCoords, err := processPolyCoordinates(polyCoordinates, dotPolygon)
+	return intersectGeos(ctx, "disc", dotPolygon, circlePoly, f, opts)
+}
+
+// intersectGeos intersects a polygon with a shape and converts the result
+// with the options
+func intersectGeos(ctx context.Context, name string, dotPolygon, shape *geom.Geometry, f frame, opts Options) (*point.GeometryCollection, error) {
+	cirGeo, err := dotPolygon.Intersection(shape)
 	if err != nil {
+		return nil, fmt.Errorf("failed intersecting polygon with %s: %w", name, err)
+	}
+	intersectedPoly := geom.Must(cirGeo, nil)
+
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	for _, coords := range intersectedPolyCoords {
-		intersectedPolyPoints := strings.Split(coords, ",")
-		for _, p := range intersectedPolyPoints {
-			latLng := strings.Split(strings.TrimSpace(p), " ")
-			lat, _ := strconv.ParseFloat(strings.Trim(latLng[0], " "), 10)
-			lng, _ := strconv.ParseFloat(strings.Trim(latLng[1], " "), 10)
-			coordinates = append(coordinates, &point.Point{Lat: lat, Lng: lng})
+	// Ok if no intersection
+	if intersectedPoly == nil {
+		return &point.GeometryCollection{}, nil
+	}
 
-		}
+	collection, err := collectionFromGeos(intersectedPoly, f, opts.AreaOnly)
+	if err != nil {
+		return nil, err
 	}
 
-	return coordinates, nil
+	collection.Polygons, err = simplifyResult(collection.Polygons, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return collection, nil
 }
 
-// GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDisc returns one polygon consisting of
-// an individual intersected polygon and a disc derived of the passed in center
-// point and radius with vincenty algorithm
-// Params:
-// Coordinates forming a polygon slice of lat,lng in degress
-// Lat center point lat in degrees
-// Lng center point lng in degrees
-// Radius off center point to create spherical disc or circle in meters
-func GetIntersectedPolygonByPolygonAndCenterPointRadiusVincentyDisc(
-	polyCoords []*point.Point,
-	lat float32,
-	lng float32,
-	radius float64) (coordinates []*point.Point, err error) {
-
-	// Catch internal C library panics
-	defer func() {
-		if e := recover(); e != nil {
-			var ok bool
-			err, ok = e.(error)
-			if !ok {
-				err = errors.Wrap(fmt.Errorf("Error: %v", e), fmt.Sprintf("Debug Stack: %s", string(debug.Stack())))
-				return
+// collectionFromGeos converts any GEOS geometry to its polygons, lines and
+// points - lines and points are dropped when only areal parts are kept and
+// parts crossing the antimeridian are split back into [-180, 180]
+func collectionFromGeos(geo *geom.Geometry, f frame, areaOnly bool) (*point.GeometryCollection, error) {
+	collection := &point.GeometryCollection{}
+	if err := appendGeos(collection, geo, f, areaOnly); err != nil {
+		return nil, err
+	}
+	return splitAntimeridian(collection, areaOnly)
+}
+
+func appendGeos(collection *point.GeometryCollection, geo *geom.Geometry, f frame, areaOnly bool) error {
+	// If nonintersecting - return empty to skip area
+	empty, err := geo.IsEmpty()
+	if err != nil {
+		return fmt.Errorf("failed checking for empty geometry: %w", err)
+	}
+	if empty {
+		return nil
+	}
+
+	geoType, err := geo.Type()
+	if err != nil {
+		return fmt.Errorf("failed getting geometry type: %w", err)
+	}
+
+	// Extract and build up polygons - one per disjoint part with exterior and
+	// interior rings (holes) kept apart so holes survive the intersection
+	switch geoType {
+	case geom.POLYGON:
+		polygon, err := polygonFromGeos(geo, f)
+		if err != nil {
+			return err
+		}
+		collection.Polygons = append(collection.Polygons, polygon)
+	case geom.LINESTRING, geom.LINEARRING:
+		// Shapes sharing an edge
+		if areaOnly {
+			return nil
+		}
+		points, err := coordsToPoints(geo, f)
+		if err != nil {
+			return err
+		}
+		collection.LineStrings = append(collection.LineStrings, points)
+	case geom.POINT:
+		// Shapes touching at a vertex
+		if areaOnly {
+			return nil
+		}
+		points, err := coordsToPoints(geo, f)
+		if err != nil {
+			return err
+		}
+		collection.Points = append(collection.Points, points...)
+	case geom.MULTIPOINT, geom.MULTILINESTRING, geom.MULTIPOLYGON, geom.GEOMETRYCOLLECTION:
+		// We have multi polygon when we have lines crossing - due to gaps initially
+		// and mixed collections when shapes overlap and touch at the same time
+		n, err := geo.NGeometry()
+		if err != nil {
+			return fmt.Errorf("failed getting %v parts: %w", geoType, err)
+		}
+		for i := 0; i < n; i++ {
+			part, err := geo.Geometry(i)
+			if err != nil {
+				return fmt.Errorf("failed getting %v part %d: %w", geoType, i, err)
+			}
+			if err := appendGeos(collection, part, f, areaOnly); err != nil {
+				return err
 			}
-			err = errors.Wrap(err, fmt.Sprintf("Debug Stack: %s", string(debug.Stack())))
 		}
-	}()
+	default:
+		return &GeometryTypeError{Type: geoType, WKT: geo.String()}
+	}
 
-	dotPolygon, err := getGeosPolygonFromCoordinates(polyCoords)
+	return nil
+}
+
+// polygonFromGeos converts a GEOS polygon to its exterior ring (shell) and
+// interior rings (holes)
+func polygonFromGeos(geo *geom.Geometry, f frame) (*point.Polygon, error) {
+	shell, err := geo.Shell()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed getting polygon shell: %w", err)
 	}
 
-	// Convert to spherical radius -> radians = distance / earth radius
-	// C lib has problems with gaps around polygon edges
-	polyCoordinates := vincenty.CreateDisc(float64(lat), float64(lng), radius) // accurate to within 0.5 mm distance or 0.000015″ of bearing
-
-	intersectedPolyCoords, err := processPolyCoordinates(polyCoordinates, dotPolygon)
+	exterior, err := coordsToPoints(shell, f)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, coords := range intersectedPolyCoords {
-		intersectedPolyPoints := strings.Split(coords, ",")
-		for _, p := range intersectedPolyPoints {
-			latLng := strings.Split(strings.TrimSpace(p), " ")
-			lat, _ := strconv.ParseFloat(strings.Trim(latLng[0], " "), 10)
-			lng, _ := strconv.ParseFloat(strings.Trim(latLng[1], " "), 10)
-			coordinates = append(coordinates, &point.Point{Lat: lat, Lng: lng})
+	holes, err := geo.Holes()
+	if err != nil {
+		return nil, fmt.Errorf("failed getting polygon holes: %w", err)
+	}
 
+	polygon := &point.Polygon{Exterior: exterior}
+	for _, hole := range holes {
+		interior, err := coordsToPoints(hole, f)
+		if err != nil {
+			return nil, err
 		}
+		polygon.Interiors = append(polygon.Interiors, interior)
 	}
 
-	return coordinates, nil
+	return polygon, nil
 }
 
-func processPolyCoordinates(polyCoordinates []*point.Point, dotPolygon *geos.Geometry) ([]string, error) {
-	// Generic collection of points - convert to coordinate string
-	var pointsStr string
-	for _, p := range polyCoordinates {
-		pointsStr += fmt.Sprintf("%f %f, ", p.Lat, p.Lng)
+// coordsToPoints converts the coordinate sequence of a GEOS linear ring or
+// line string to points out of the frame plane
+func coordsToPoints(geo *geom.Geometry, f frame) ([]*point.Point, error) {
+	coords, err := geo.Coords()
+	if err != nil {
+		return nil, fmt.Errorf("failed getting coordinate sequence: %w", err)
+	}
+
+	// Closures past a pole come back onto it
+	points := make([]*point.Point, 0, len(coords))
+	for _, c := range coords {
+		p := f.point(c)
+		if len(points) > 0 && *points[len(points)-1] == *p {
+			continue
+		}
+		points = append(points, p)
 	}
 
-	pointsStr = pointsStr[:len(pointsStr)-2]
-	sepPoints := strings.Split(pointsStr, ",")
+	return points, nil
+}
+
+// pointsToCoords converts points to a closed GEOS coordinate sequence in the
+// frame plane around the reference lng
+func pointsToCoords(points []*point.Point, f frame, reference float64) []geom.Coord {
+	var coords []geom.Coord
+
+	// Rings around a pole are circles in a projection but need closing along
+	// the pole in the lat,lng plane - across the whole frame lng window and
+	// past the pole so the pole itself is inside
+	if ring, pole, ok := poleRing(points); ok && f.proj == nil {
+		for _, p := range disc.EnclosePole(f.densifyPoints(ring, true), pole, f.lng) {
+			lat := p.Lat
+			if math.Abs(lat) == 90.0 {
+				lat = math.Copysign(90.0+POLE_OVERSHOOT, lat)
+			}
+			coords = append(coords, geom.NewCoord(lat, p.Lng))
+		}
+	} else if ok {
+		coords = f.coords(f.densifyPoints(ring, true), reference)
+	} else {
+		coords = f.coords(f.densifyPoints(points, true), reference)
+	}
 
 	// NOTE:
-	// Split by comma - repeat the first point to close polygon
+	// Repeat the first point to close polygon
 	// If we do not do this, it will panic with: geos: IllegalArgumentException: Points of LinearRing do not form a closed linestring
 	// Per Geos C++ Port of Original JTP - Java Topology Suite - a valid polygon
 	// is a closed circuit with exact points at the beginning and end of the
 	// polygon points sequence
-	rawCirclePolygonStr := fmt.Sprintf("POLYGON ((%s))", pointsStr+", "+sepPoints[0]) // take the first and attach the end to close the polygon
+	if len(coords) > 0 && coords[0] != coords[len(coords)-1] {
+		coords = append(coords, coords[0])
+	}
 
-	// Final intersected polygon - do this for DOT with service radius only
-	geo, err := geos.FromWKT(rawCirclePolygonStr)
-	if err != nil {
-		return nil, err
+	return coords
+}
+
+// getGeosPolygon builds the GEOS polygon for incoming coordinates - repaired
+// first when the options ask for it
+func getGeosPolygon(coordinates []*point.Point, f frame, opts Options) (*geom.Geometry, error) {
+	if !opts.Repair {
+		return getGeosPolygonFromCoordinates(coordinates, f)
 	}
-	circlePoly := geos.Must(geo, nil)
 
-	cirGeo, err := dotPolygon.Intersection(circlePoly)
+	return getGeosRepairedGeometry(point.Ring(coordinates), f)
+}
+
+// getGeosRepairedGeometry builds the GEOS polygon or multi polygon of
+// polygonal input with every ring repaired first
+func getGeosRepairedGeometry(polygonal point.Polygonal, f frame) (*geom.Geometry, error) {
+	multiPolygon, err := repairPolygonal(polygonal)
 	if err != nil {
 		return nil, err
 	}
-	intersectedPoly := geos.Must(cirGeo, nil)
 
-	// Ok if no intersection
-	if intersectedPoly == nil {
-		return nil, nil
+	return getGeosGeometryFromMultiPolygon(multiPolygon, f)
+}
+
+// Expected format - slice of coordinate points
+func getGeosPolygonFromCoordinates(coordinates []*point.Point, f frame) (geosPoly *geom.Geometry, err error) {
+	return getGeosPolygonFromPolygon(&point.Polygon{Exterior: coordinates}, f)
+}
+
+// getGeosPolygonFromPolygon builds a GEOS polygon with holes - a GEOS multi
+// polygon when it reaches past the frame lng window and is folded into it
+func getGeosPolygonFromPolygon(polygon *point.Polygon, f frame) (*geom.Geometry, error) {
+	if polygon == nil {
+		return nil, &PolygonError{Reason: "nil polygon", Err: ErrInvalidPolygon}
 	}
 
-	polyType, err := intersectedPoly.Type()
-	if err != nil {
-		return nil, errors.Wrap(err, "failed getting polygon type")
+	rings := append([][]*point.Point{polygon.Exterior}, polygon.Interiors...)
+	for _, ring := range rings {
+		for _, p := range ring {
+			if p == nil {
+				return nil, &PolygonError{Coordinates: ring, Reason: "nil point", Err: ErrInvalidPolygon}
+			}
+			// The geometry engines cannot build on NaN or infinite coordinates
+			if !validCoordinate(p) {
+				return nil, &PolygonError{Coordinates: ring, Reason: ValidationIssue{Reason: InvalidCoordinate, Location: p}.String(), Err: ErrInvalidPolygon}
+			}
+		}
+
+		if countDistinctPoints(ring) < 3 {
+			return nil, &PolygonError{Coordinates: ring, Reason: "fewer than three distinct points", Err: ErrTooFewPoints}
+		}
+
+		// A band around the globe is no polygon in a plane around one point
+		if f.proj != nil && fullWidth(ring) {
+			return nil, &PolygonError{Coordinates: ring, Reason: "ring around the whole globe in a projection", Err: ErrInvalidPolygon}
+		}
 	}
 
-	// If nonintersecting - return empty to skip area
-	if intersectedPoly.String()[len(intersectedPoly.String())-5:] == "EMPTY" {
-		return nil, nil
-	}
-
-	// Extract and build up coordinates
-	var intersectedPolyCoords []string
-	switch polyType {
-	case geos.POLYGON:
-		polyStr := intersectedPoly.String()
-		// TODO - polygon with another one inside is a hole
-		// C lib has issues hanlding this
-		polyStr = strings.Replace(polyStr, "), (", ", ", -1) // sanitize this format ), (
-		intersectedPolyCoords = append(intersectedPolyCoords, polyStr[10:len(polyStr)-2])
-	case geos.MULTIPOLYGON:
-		// We have multi polygon when we have lines crossing - due to gaps initially
-		n, err := intersectedPoly.NGeometry()
+	// Holes are placed around the middle of their exterior
+	shell := pointsToCoords(polygon.Exterior, f, f.lng)
+	holes := make([][]geom.Coord, len(polygon.Interiors))
+	for i, interior := range polygon.Interiors {
+		holes[i] = pointsToCoords(interior, f, middleLng(shell))
+	}
+
+	build := func(shift float64) (*geom.Geometry, error) {
+		shifted := make([][]geom.Coord, len(holes))
+		for i, hole := range holes {
+			shifted[i] = shiftCoords(hole, shift)
+		}
+
+		geo, err := geom.NewPolygon(shiftCoords(shell, shift), shifted...)
 		if err != nil {
-			log.Fatal(err)
+			return nil, &PolygonError{Coordinates: polygon.Exterior, Reason: err.Error(), Err: ErrInvalidPolygon}
 		}
-		for i := 0; i < n; i++ {
-			geo := geos.Must(intersectedPoly.Geometry(i))
-			points := geo.String()[10 : len(geo.String())-2]
-			points = strings.Replace(points, "), (", ", ", -1) // sanitize this format ), (
-			intersectedPolyCoords = append(intersectedPolyCoords, points)
+
+		if geo == nil {
+			return nil, &PolygonError{Coordinates: polygon.Exterior, Reason: "failed to generate geometric shape from coordinates", Err: ErrInvalidPolygon}
 		}
-	case geos.LINESTRING:
-		lineStr := intersectedPoly.String()
-		lineStr = strings.Replace(lineStr, "), (", ", ", -1) // sanitize this format ), (
-		intersectedPolyCoords = append(intersectedPolyCoords, lineStr[12:len(lineStr)-2])
-	default:
-		log.Fatalln("Unknown type", polyType, intersectedPoly)
+
+		return geo, nil
+	}
+
+	if f.fits(shell) {
+		return build(0)
 	}
 
-	return intersectedPolyCoords, nil
+	return f.fold(geom.POLYGON, build)
 }
 
-// Expected format - slice of coordinate points
-func getGeosPolygonFromCoordinates(coordinates []*point.Point) (geosPoly *geos.Geometry, err error) {
-	var points string
-	for _, point := range coordinates {
-		points += fmt.Sprintf("%f %f, ", point.Lat, point.Lng)
+// getGeosGeometryFromMultiPolygon builds a GEOS polygon for a single part or
+// a GEOS multi polygon otherwise
+func getGeosGeometryFromMultiPolygon(multiPolygon point.MultiPolygon, f frame) (*geom.Geometry, error) {
+	geoms := make([]*geom.Geometry, 0, len(multiPolygon))
+	for _, polygon := range multiPolygon {
+		geo, err := getGeosPolygonFromPolygon(polygon, f)
+		if err != nil {
+			return nil, err
+		}
+
+		// Folded parts are multi polygons themselves
+		if geoType, err := geo.Type(); err == nil && geoType == geom.MULTIPOLYGON {
+			if geoms, err = appendParts(geoms, geo, geom.POLYGON); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		geoms = append(geoms, geo)
 	}
-	points = points[:len(points)-2]
 
-	// NOTE:
-	// Split by comma - repeat the first point to close polygon
-	// If we do not do this, it will panic with: geos: IllegalArgumentException: Points of LinearRing do not form a closed linestring
-	// Per Geos C++ Port of Original JTP - Java Topology Suite - a valid polygon
-	// is a closed circuit with exact points at the beginning and end of the
-	// polygon points sequence
-	sepPoints := strings.Split(points, ",")
-	output := fmt.Sprintf("POLYGON ((%s))", points+", "+sepPoints[0])
+	if len(geoms) == 1 {
+		return geoms[0], nil
+	}
+
+	return geom.NewCollection(geom.MULTIPOLYGON, geoms...)
+}
 
-	geo, err := geos.FromWKT(output)
+// appendParts appends new copies of every polygon or every line string of a
+// GEOS geometry - the parts of other types are skipped
+func appendParts(parts []*geom.Geometry, geo *geom.Geometry, partType geom.GeometryType) ([]*geom.Geometry, error) {
+	empty, err := geo.IsEmpty()
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed checking for empty geometry: %w", err)
+	}
+	if empty {
+		return parts, nil
 	}
 
-	if geo == nil {
-		return nil, fmt.Errorf("failed to generate decoded geometric shape from wkt - Incoming Coordinates: %v - Output: %s",
-			points, output)
+	geoType, err := geo.Type()
+	if err != nil {
+		return nil, fmt.Errorf("failed getting geometry type: %w", err)
 	}
 
-	geosPoly = geos.Must(geo, nil)
+	// Parts of collections are owned by them - copy the coordinates
+	switch geoType {
+	case geom.POLYGON:
+		if partType != geom.POLYGON {
+			return parts, nil
+		}
+
+		shell, err := geo.Shell()
+		if err != nil {
+			return nil, fmt.Errorf("failed getting polygon shell: %w", err)
+		}
+		rings := []*geom.Geometry{shell}
+		holes, err := geo.Holes()
+		if err != nil {
+			return nil, fmt.Errorf("failed getting polygon holes: %w", err)
+		}
+		rings = append(rings, holes...)
 
-	return geosPoly, nil
+		coords := make([][]geom.Coord, len(rings))
+		for i, ring := range rings {
+			if coords[i], err = ring.Coords(); err != nil {
+				return nil, fmt.Errorf("failed getting coordinate sequence: %w", err)
+			}
+		}
+
+		polygon, err := geom.NewPolygon(coords[0], coords[1:]...)
+		if err != nil {
+			return nil, fmt.Errorf("failed copying polygon: %w", err)
+		}
+		return append(parts, polygon), nil
+	case geom.LINESTRING, geom.LINEARRING:
+		if partType != geom.LINESTRING {
+			return parts, nil
+		}
+
+		coords, err := geo.Coords()
+		if err != nil {
+			return nil, fmt.Errorf("failed getting coordinate sequence: %w", err)
+		}
+
+		line, err := geom.NewLineString(coords...)
+		if err != nil {
+			return nil, fmt.Errorf("failed copying line string: %w", err)
+		}
+		return append(parts, line), nil
+	case geom.MULTIPOINT, geom.MULTILINESTRING, geom.MULTIPOLYGON, geom.GEOMETRYCOLLECTION:
+		n, err := geo.NGeometry()
+		if err != nil {
+			return nil, fmt.Errorf("failed getting %v parts: %w", geoType, err)
+		}
+		for i := 0; i < n; i++ {
+			part, err := geo.Geometry(i)
+			if err != nil {
+				return nil, fmt.Errorf("failed getting %v part %d: %w", geoType, i, err)
+			}
+			if parts, err = appendParts(parts, part, partType); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return parts, nil
+}
+
+// countDistinctPoints returns the number of distinct points
+func countDistinctPoints(coordinates []*point.Point) int {
+	distinct := make(map[point.Point]struct{}, len(coordinates))
+	for _, p := range coordinates {
+		distinct[*p] = struct{}{}
+	}
+	return len(distinct)
 }
@@ -2,7 +2,4 @@ module github.com/jdejesus007/gogeospace
 
 go 1.13
 
-require (
-	github.com/jdejesus007/gogeos v0.1.5
-	github.com/pkg/errors v0.8.1
-)
+require github.com/jdejesus007/gogeos v0.1.5
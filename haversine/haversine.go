@@ -3,7 +3,7 @@ package haversine
 import (
 	"math"
 
-	"github.com/jdejesus007/gogeospace/constants"
+	"github.com/jdejesus007/gogeospace/disc"
 	"github.com/jdejesus007/gogeospace/point"
 	"github.com/jdejesus007/gogeospace/utils"
 )
@@ -15,24 +15,66 @@ const (
 
 // lat1, ln2 in degrees
 // radius in radians -> ditance / Earth Radius gives radians
-func CreateDisc(lat1, lng1, radius float64) []*point.Point {
+// optional disc options set the vertex count or max error in meters
+func CreateDisc(lat1, lng1, radius float64, opts ...disc.Options) []*point.Point {
+	coordinates, _ := CreateDiscWithErrorBound(lat1, lng1, radius, disc.Resolve(opts...))
+	return coordinates
+}
+
+// CreateDiscWithErrorBound creates a disc like CreateDisc and also returns the
+// achieved max chord-to-arc error in meters - nil for a disc reaching past
+// both poles or without a disc.Valid center and radius
+func CreateDiscWithErrorBound(lat1, lng1, radius float64, opts disc.Options) ([]*point.Point, float64) {
+	if !disc.Valid(lat1, lng1, radius) {
+		return nil, 0
+	}
 
-	steps := constants.NUM_STEPS_PRECISION               // precision
-	radiusRad := radius / float64(EARTH_RADIUS_CONSTANT) // meters
+	steps, maxError := disc.Steps(radius, EARTH_RADIUS_CONSTANT, opts) // precision
+	radiusRad := radius / float64(EARTH_RADIUS_CONSTANT)               // meters
 	lat1Rad := utils.DegreesToRadians(lat1)
-	lng1Rad := utils.DegreesToRadians(lng1)
 
-	var coordinates []*point.Point
-	for i := 0.0; i < steps; i++ {
-		bearingRad := utils.DegreesToRadians(float64(i * -360.0 / steps))
+	coordinates := make([]*point.Point, 0, steps)
+	for i := 0; i < steps; i++ {
+		lat2, lng2 := Destination(lat1, lng1, radius, float64(i)*-360.0/float64(steps))
+		coordinates = append(coordinates, &point.Point{Lat: lat2, Lng: lng2})
+	}
 
-		lat2Rad := math.Asin(math.Sin(lat1Rad)*math.Cos(radiusRad) + math.Cos(lat1Rad)*math.Sin(radiusRad)*math.Cos(bearingRad))
-		lng2Rad := lng1Rad + math.Atan2(math.Sin(bearingRad)*math.Sin(radiusRad)*math.Cos(lat1Rad), math.Cos(radiusRad)-math.Sin(lat1Rad)*math.Sin(lat2Rad))
+	// A disc reaching past one pole winds around it - close it along the pole
+	north := radiusRad > math.Pi/2.0-lat1Rad
+	south := radiusRad > math.Pi/2.0+lat1Rad
+	if north && south {
+		// Winding around neither pole the ring cannot tell its inside apart
+		return nil, maxError
+	}
+	if north != south {
+		coordinates = disc.EnclosePole(coordinates, math.Copysign(90.0, lat1), 0)
+	}
 
-		lat2 := utils.RadToDegrees(lat2Rad)
-		lng2 := utils.RadToDegrees(lng2Rad)
+	return coordinates, maxError
+}
 
-		coordinates = append(coordinates, &point.Point{Lat: lat2, Lng: lng2})
-	}
-	return coordinates
+// Generator creates haversine discs - a spherical Earth approximation
+type Generator struct {
+	// Options set the vertex count or max error in meters of each disc
+	Options disc.Options
+}
+
+// CreateDisc creates a disc with center lat, lng in degrees and radius in meters
+func (g Generator) CreateDisc(lat, lng, radius float64) []*point.Point {
+	return CreateDisc(lat, lng, radius, g.Options)
+}
+
+// Destination returns the point reached from lat1, lng1 in degrees after
+// distance meters along the great circle starting at bearing in degrees - lng
+// wrapped into [-180, 180]
+func Destination(lat1, lng1, distance, bearing float64) (float64, float64) {
+	distanceRad := distance / EARTH_RADIUS_CONSTANT
+	lat1Rad := utils.DegreesToRadians(lat1)
+	lng1Rad := utils.DegreesToRadians(lng1)
+	bearingRad := utils.DegreesToRadians(bearing)
+
+	lat2Rad := math.Asin(math.Sin(lat1Rad)*math.Cos(distanceRad) + math.Cos(lat1Rad)*math.Sin(distanceRad)*math.Cos(bearingRad))
+	lng2Rad := lng1Rad + math.Atan2(math.Sin(bearingRad)*math.Sin(distanceRad)*math.Cos(lat1Rad), math.Cos(distanceRad)-math.Sin(lat1Rad)*math.Sin(lat2Rad))
+
+	return utils.RadToDegrees(lat2Rad), utils.NormalizeLongitude(utils.RadToDegrees(lng2Rad))
 }
@@ -0,0 +1,101 @@
+package haversine
+
+import (
+	"math"
+
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/utils"
+)
+
+// Distance returns the great circle distance in meters between two lat,lng
+// points in degrees on a spherical Earth
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	lat1Rad := utils.DegreesToRadians(lat1)
+	lat2Rad := utils.DegreesToRadians(lat2)
+	sinDeltaLat := math.Sin((lat2Rad - lat1Rad) / 2.0)
+	sinDeltaLng := math.Sin(utils.DegreesToRadians(lng2-lng1) / 2.0)
+
+	h := sinDeltaLat*sinDeltaLat + math.Cos(lat1Rad)*math.Cos(lat2Rad)*sinDeltaLng*sinDeltaLng
+	return 2.0 * EARTH_RADIUS_CONSTANT * math.Asin(math.Min(1.0, math.Sqrt(h)))
+}
+
+// Bearing returns the starting bearing in degrees clockwise from north of the
+// great circle from lat1, lng1 to lat2, lng2 in degrees
+func Bearing(lat1, lng1, lat2, lng2 float64) float64 {
+	lat1Rad := utils.DegreesToRadians(lat1)
+	lat2Rad := utils.DegreesToRadians(lat2)
+	deltaLngRad := utils.DegreesToRadians(lng2 - lng1)
+
+	y := math.Sin(deltaLngRad) * math.Cos(lat2Rad)
+	x := math.Cos(lat1Rad)*math.Sin(lat2Rad) - math.Sin(lat1Rad)*math.Cos(lat2Rad)*math.Cos(deltaLngRad)
+	return utils.RadToDegrees(math.Atan2(y, x))
+}
+
+// RingLength returns the length in meters of a ring of lat,lng points in
+// degrees including the closing edge
+func RingLength(ring []*point.Point) float64 {
+	var length float64
+	for i := range ring {
+		p1, p2 := ring[i], ring[(i+1)%len(ring)]
+		length += Distance(p1.Lat, p1.Lng, p2.Lat, p2.Lng)
+	}
+	return length
+}
+
+// RingArea returns the area in square meters enclosed by a ring of lat,lng
+// points in degrees on a spherical Earth - open or closed, either winding
+func RingArea(ring []*point.Point) float64 {
+	return math.Abs(SphericalExcess(ring)) * EARTH_RADIUS_CONSTANT * EARTH_RADIUS_CONSTANT
+}
+
+// SphericalExcess returns the signed area in steradians enclosed by a ring of
+// lat,lng points in degrees on the unit sphere - positive for counter
+// clockwise rings. Of the two areas a ring splits the sphere into the smaller
+// one is taken - rings winding around a pole enclose it
+func SphericalExcess(ring []*point.Point) float64 {
+	var excess, winding float64
+	for i := range ring {
+		p1, p2 := ring[i], ring[(i+1)%len(ring)]
+
+		// Per edge excess of the triangle with the pole
+		tanLat1 := math.Tan(utils.DegreesToRadians(p1.Lat) / 2.0)
+		tanLat2 := math.Tan(utils.DegreesToRadians(p2.Lat) / 2.0)
+		deltaLngRad := math.Remainder(utils.DegreesToRadians(p2.Lng-p1.Lng), 2.0*math.Pi)
+
+		excess += 2.0 * math.Atan2(math.Tan(deltaLngRad/2.0)*(tanLat1+tanLat2), 1.0+tanLat1*tanLat2)
+		winding += deltaLngRad
+	}
+
+	// A ring winding around a pole counts the full turn around it
+	if math.Abs(winding) > math.Pi {
+		excess -= math.Copysign(2.0*math.Pi, winding)
+	}
+
+	if math.Abs(excess) > 2.0*math.Pi {
+		excess -= math.Copysign(4.0*math.Pi, excess)
+	}
+
+	return excess
+}
+
+// Intermediate returns the point a fraction of the way along the great circle
+// from lat1, lng1 to lat2, lng2 in degrees
+func Intermediate(lat1, lng1, lat2, lng2, fraction float64) (float64, float64) {
+	lat1Rad := utils.DegreesToRadians(lat1)
+	lng1Rad := utils.DegreesToRadians(lng1)
+	lat2Rad := utils.DegreesToRadians(lat2)
+	lng2Rad := utils.DegreesToRadians(lng2)
+
+	delta := Distance(lat1, lng1, lat2, lng2) / EARTH_RADIUS_CONSTANT
+	if delta == 0 {
+		return lat1, lng1
+	}
+
+	a := math.Sin((1.0-fraction)*delta) / math.Sin(delta)
+	b := math.Sin(fraction*delta) / math.Sin(delta)
+	x := a*math.Cos(lat1Rad)*math.Cos(lng1Rad) + b*math.Cos(lat2Rad)*math.Cos(lng2Rad)
+	y := a*math.Cos(lat1Rad)*math.Sin(lng1Rad) + b*math.Cos(lat2Rad)*math.Sin(lng2Rad)
+	z := a*math.Sin(lat1Rad) + b*math.Sin(lat2Rad)
+
+	return utils.RadToDegrees(math.Atan2(z, math.Hypot(x, y))), utils.RadToDegrees(math.Atan2(y, x))
+}
@@ -0,0 +1,40 @@
+package haversine
+
+import (
+	"math"
+
+	"github.com/jdejesus007/gogeospace/disc"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+// CreateSector creates a wedge with center lat1, lng1 out to radius in meters
+// clockwise from start to end bearing in degrees - such as antenna coverage.
+// Optional disc options set the vertex count or max error of the whole circle
+func CreateSector(lat1, lng1, radius, startBearing, endBearing float64, opts ...disc.Options) []*point.Point {
+	return disc.Sector(lat1, lng1, radius, startBearing, endBearing, EARTH_RADIUS_CONSTANT, disc.Resolve(opts...), Destination)
+}
+
+// CreateAnnulus creates a ring shaped polygon with center lat1, lng1 between
+// the inner and outer radius in meters - the inner disc is its hole. Returns
+// nil unless the inner radius is smaller than the outer, both are finite and
+// the outer disc stays clear of one pole
+func CreateAnnulus(lat1, lng1, innerRadius, outerRadius float64, opts ...disc.Options) *point.Polygon {
+	if !(innerRadius < outerRadius) || math.IsInf(innerRadius, 0) {
+		return nil
+	}
+
+	annulus := &point.Polygon{Exterior: CreateDisc(lat1, lng1, outerRadius, opts...)}
+	if annulus.Exterior == nil {
+		return nil
+	}
+	if innerRadius > 0 {
+		annulus.Interiors = [][]*point.Point{CreateDisc(lat1, lng1, innerRadius, opts...)}
+	}
+	return annulus
+}
+
+// CreateEllipse creates an ellipse with center lat1, lng1, semi-axes in meters
+// and its semi-major axis rotated to azimuth in degrees clockwise from north
+func CreateEllipse(lat1, lng1, semiMajor, semiMinor, azimuth float64, opts ...disc.Options) []*point.Point {
+	return disc.Ellipse(lat1, lng1, semiMajor, semiMinor, azimuth, EARTH_RADIUS_CONSTANT, disc.Resolve(opts...), Destination)
+}
@@ -0,0 +1,100 @@
+package gogeospace
+
+import (
+	"math"
+
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/vincenty"
+)
+
+// Method selects the Earth model distances and areas are measured on
+type Method int
+
+const (
+	// MethodVincenty measures on the WGS-84 ellipsoid
+	MethodVincenty Method = iota
+	// MethodHaversine measures on a sphere with haversine.EARTH_RADIUS_CONSTANT
+	MethodHaversine
+)
+
+// Area returns the area in square meters of rings, polygons or multi polygons
+// - holes are subtracted. Edges are great circles except edges along a
+// parallel which follow the parallel like the edges of overlay results
+// Params:
+// Polygonal rings, polygons or multi polygons of lat,lng in degrees
+// Method the Earth model to measure on
+func Area(polygonal point.Polygonal, method Method) (float64, error) {
+	ringArea := vincenty.RingArea
+	if method == MethodHaversine {
+		ringArea = haversine.RingArea
+	}
+
+	var area float64
+	err := eachRing(polygonal, func(ring []*point.Point, hole bool) {
+		if hole {
+			area -= ringArea(ring)
+			return
+		}
+		area += ringArea(ring)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Max(0, area), nil
+}
+
+// Perimeter returns the length in meters of the boundary of rings, polygons
+// or multi polygons - hole boundaries are included. Edges are measured like
+// Area measures them
+// Params:
+// Polygonal rings, polygons or multi polygons of lat,lng in degrees
+// Method the Earth model to measure on
+func Perimeter(polygonal point.Polygonal, method Method) (float64, error) {
+	ringLength := vincenty.RingLength
+	if method == MethodHaversine {
+		ringLength = haversine.RingLength
+	}
+
+	var perimeter float64
+	err := eachRing(polygonal, func(ring []*point.Point, _ bool) {
+		perimeter += ringLength(ring)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return perimeter, nil
+}
+
+// eachRing calls fn with every exterior and hole ring of the polygonal input
+// - rings with nil points are rejected
+func eachRing(polygonal point.Polygonal, fn func(ring []*point.Point, hole bool)) error {
+	if polygonal == nil {
+		return nil
+	}
+
+	for _, polygon := range polygonal.Polygons() {
+		if polygon == nil {
+			continue
+		}
+
+		rings := append([][]*point.Point{polygon.Exterior}, polygon.Interiors...)
+		for i, ring := range rings {
+			for _, p := range ring {
+				if p == nil {
+					return &PolygonError{Coordinates: ring, Reason: "nil point", Err: ErrInvalidPolygon}
+				}
+			}
+			// Closures along a pole are not part of the boundary and edges
+			// around the whole globe do not take the shorter way
+			if raw, _, ok := poleRing(ring); ok {
+				ring = raw
+			}
+			fn(fillParallels(openRing(ring)), i > 0)
+		}
+	}
+
+	return nil
+}
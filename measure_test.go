@@ -0,0 +1,68 @@
+package gogeospace
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+func TestAreaAndPerimeterKnownValues(t *testing.T) {
+	// WGS-84 surface area and meridian arcs from GeographicLib - equator and
+	// parallel arcs from the semi major axis and prime vertical radius
+	const (
+		ellipsoidArea     = 510065621724088.5
+		meridianQuadrant  = 10001965.729
+		equatorQuadrant   = 10018754.171
+		meridianDegree    = 110574.389
+		equatorDegree     = 111319.491
+		parallelDegreeAt1 = 111302.650
+	)
+	radius := haversine.EARTH_RADIUS_CONSTANT
+	sphereArea := 4 * math.Pi * radius * radius
+	sphereQuadrant := math.Pi * radius / 2
+
+	octant := box(0, 0, 90, 90)
+	lune := box(-90, 0, 90, 1)
+	opposite := point.MultiPolygon{{Exterior: box(0, 0, 90, 90)}, {Exterior: box(-90, 90, 0, 180)}}
+
+	tests := []struct {
+		name      string
+		polygonal point.Polygonal
+		method    Method
+		area      float64
+		perimeter float64
+	}{
+		{name: "ellipsoid octant", polygonal: octant, method: MethodVincenty,
+			area: ellipsoidArea / 8, perimeter: 2*meridianQuadrant + equatorQuadrant},
+		{name: "ellipsoid lune", polygonal: lune, method: MethodVincenty,
+			area: ellipsoidArea / 360, perimeter: 4 * meridianQuadrant},
+		{name: "ellipsoid opposite octants", polygonal: opposite, method: MethodVincenty,
+			area: ellipsoidArea / 4, perimeter: 4*meridianQuadrant + 2*equatorQuadrant},
+		{name: "ellipsoid degree cell", polygonal: box(0, 0, 1, 1), method: MethodVincenty,
+			perimeter: 2*meridianDegree + equatorDegree + parallelDegreeAt1},
+		{name: "sphere octant", polygonal: octant, method: MethodHaversine,
+			area: sphereArea / 8, perimeter: 3 * sphereQuadrant},
+		{name: "sphere lune", polygonal: lune, method: MethodHaversine,
+			area: sphereArea / 360, perimeter: 4 * sphereQuadrant},
+		{name: "sphere opposite octants", polygonal: opposite, method: MethodHaversine,
+			area: sphereArea / 4, perimeter: 6 * sphereQuadrant},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.area > 0 {
+				area, err := Area(test.polygonal, test.method)
+				if err != nil || math.Abs(area-test.area) > 1e-9*test.area {
+					t.Errorf("Area() = %v, %v, want %v", area, err, test.area)
+				}
+			}
+
+			perimeter, err := Perimeter(test.polygonal, test.method)
+			if err != nil || math.Abs(perimeter-test.perimeter) > 0.01 {
+				t.Errorf("Perimeter() = %v, %v, want %v", perimeter, err, test.perimeter)
+			}
+		})
+	}
+}
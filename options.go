@@ -0,0 +1,89 @@
+package gogeospace
+
+import (
+	"time"
+
+	"github.com/jdejesus007/gogeospace/geom"
+)
+
+// Projection selects the plane polygon operations are run in
+type Projection int
+
+const (
+	// ProjectionNone runs polygon operations on raw lat,lng degrees as a flat
+	// plane - edges distort at high latitudes and over long spans
+	ProjectionNone Projection = iota
+	// ProjectionAzimuthalEquidistant runs polygon operations in a local
+	// azimuthal equidistant projection centered on the inputs - distances
+	// from the center are true so discs stay circular
+	ProjectionAzimuthalEquidistant
+	// ProjectionLambertEqualArea runs polygon operations in a local Lambert
+	// azimuthal equal-area projection centered on the inputs - areas are true
+	ProjectionLambertEqualArea
+	// ProjectionGnomonic runs polygon operations in a local gnomonic
+	// projection centered on the inputs - edges follow great circles. Inputs
+	// must lie within 90 degrees of their center
+	ProjectionGnomonic
+)
+
+// Options are optional settings for polygon operations - the zero value keeps
+// the default behavior
+type Options struct {
+	// Projection is the plane polygon operations run in - results are always
+	// projected back to lat,lng degrees
+	Projection Projection
+
+	// Generator creates the disc for disc intersections - defaults to the
+	// vincenty generator
+	Generator DiscGenerator
+
+	// AreaOnly keeps only the areal parts of results - lines and points left
+	// where shapes only touch are dropped
+	AreaOnly bool
+
+	// Repair runs RepairPolygon on every ring of incoming rings, polygons and
+	// multi polygons before they are used - drops repeated points, closes
+	// rings and resolves self-intersections. Polygons of geometry
+	// collections are used as they are
+	Repair bool
+
+	// Method is the Earth model areas are measured on - defaults to the
+	// WGS-84 ellipsoid
+	Method Method
+
+	// BufferStyle sets the quadrant segments, end cap and join styles of
+	// BufferMeters - zero fields default to 8 round segments per quadrant,
+	// round caps, round joins and a mitre limit of 5
+	BufferStyle geom.BufferOpts
+
+	// SimplifyMeters simplifies the polygons of intersection, overlay and
+	// disc results topology preserving with this tolerance in meters - zero
+	// keeps every vertex
+	SimplifyMeters float64
+
+	// DensifyMeters inserts points along input edges longer than this many
+	// meters so they follow the geodesic of the Method Earth model - zero
+	// keeps edges straight in the plane
+	DensifyMeters float64
+
+	// Timeout bounds each operation - it is checked between steps and the
+	// operation fails with context.DeadlineExceeded at the next step once the
+	// time is up. A running step such as a GEOS call or disc generation is
+	// not interrupted so an operation may overrun by one step. Zero never
+	// times out
+	Timeout time.Duration
+
+	// MaxVertices bounds the vertices of the inputs and generated discs and
+	// shapes of each operation counted after DensifyMeters - it fails with
+	// ErrVertexBudget before any geometry is built over budget. Zero is
+	// unlimited
+	MaxVertices int
+}
+
+// getOptions returns the first of the optional options or the defaults
+func getOptions(opts []Options) Options {
+	if len(opts) == 0 {
+		return Options{}
+	}
+	return opts[0]
+}
@@ -0,0 +1,185 @@
+package gogeospace
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+// OverlapClass describes how two polygons relate to each other
+type OverlapClass int
+
+const (
+	// OverlapDisjoint polygons share no point
+	OverlapDisjoint OverlapClass = iota
+	// OverlapTouching polygons share boundary points but no area
+	OverlapTouching
+	// OverlapPartial polygons share some but not all of their area
+	OverlapPartial
+	// OverlapAInsideB polygon a lies entirely inside polygon b
+	OverlapAInsideB
+	// OverlapBInsideA polygon b lies entirely inside polygon a
+	OverlapBInsideA
+	// OverlapEqual polygons cover the same area
+	OverlapEqual
+)
+
+// DE-9IM patterns of the overlap classes - checked most specific first
+const (
+	equalPattern    = "T*F**FFF*"
+	withinPattern   = "T*F**F***"
+	containPattern  = "T*****FF*"
+	disjointPattern = "FF*FF****"
+)
+
+func (c OverlapClass) String() string {
+	switch c {
+	case OverlapDisjoint:
+		return "disjoint"
+	case OverlapTouching:
+		return "touching"
+	case OverlapPartial:
+		return "partial overlap"
+	case OverlapAInsideB:
+		return "a inside b"
+	case OverlapBInsideA:
+		return "b inside a"
+	case OverlapEqual:
+		return "equal"
+	default:
+		return fmt.Sprintf("OverlapClass(%d)", int(c))
+	}
+}
+
+// Overlap is how much two polygons overlap
+type Overlap struct {
+	// Class how the polygons relate
+	Class OverlapClass `json:"class"`
+	// Intersection the shared area - one polygon per disjoint part
+	Intersection point.MultiPolygon `json:"intersection,omitempty"`
+	// Area of the intersection in square meters
+	Area float64 `json:"area"`
+	// PercentOfA share of the area of a covered by b from 0 to 100
+	PercentOfA float64 `json:"percentOfA"`
+	// PercentOfB share of the area of b covered by a from 0 to 100
+	PercentOfB float64 `json:"percentOfB"`
+}
+
+// PolygonOverlap takes two arrays of coordinates and returns how much the
+// polygons overlap - the companion of DoPolygonsIntersect for zone conflicts
+// Params:
+// CoordinatesA, CoordinatesB forming polygon slices of lat,lng in degrees
+// Opts optional settings such as the projection and the Earth model areas are
+// measured on
+func PolygonOverlap(coordinatesA, coordinatesB []*point.Point, opts ...Options) (overlap *Overlap, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	options := getOptions(opts)
+	geoA, geoB, f, err := getGeosGeometryPair(point.Ring(coordinatesA), point.Ring(coordinatesB), options)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix, err := geoA.Relate(geoB)
+	if err != nil {
+		return nil, fmt.Errorf("failed relating polygons: %w", err)
+	}
+
+	overlap = &Overlap{Class: overlapClass(matrix)}
+	if overlap.Class == OverlapDisjoint || overlap.Class == OverlapTouching {
+		return overlap, nil
+	}
+
+	intersection, err := geoA.Intersection(geoB)
+	if err != nil {
+		return nil, fmt.Errorf("failed intersecting polygons: %w", err)
+	}
+
+	collection, err := collectionFromGeos(intersection, f, true)
+	if err != nil {
+		return nil, err
+	}
+	overlap.Intersection = collection.Polygons
+
+	overlap.Area, err = Area(overlap.Intersection, options.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	// Measure the polygons as built so repaired rings are measured repaired
+	for i, geo := range []*geom.Geometry{geoA, geoB} {
+		polygons, err := collectionFromGeos(geo, f, true)
+		if err != nil {
+			return nil, err
+		}
+
+		area, err := Area(polygons.Polygons, options.Method)
+		if err != nil {
+			return nil, err
+		}
+
+		percent := 0.0
+		if area > 0 {
+			percent = math.Min(100.0, overlap.Area/area*100.0)
+		}
+
+		if i == 0 {
+			overlap.PercentOfA = percent
+		} else {
+			overlap.PercentOfB = percent
+		}
+	}
+
+	// Measured before simplifying so the metrics stay exact
+	overlap.Intersection, err = simplifyResult(overlap.Intersection, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return overlap, nil
+}
+
+// overlapClass classifies a DE-9IM intersection matrix of two polygons
+func overlapClass(matrix string) OverlapClass {
+	switch {
+	case matchesPattern(matrix, equalPattern):
+		return OverlapEqual
+	case matchesPattern(matrix, withinPattern):
+		return OverlapAInsideB
+	case matchesPattern(matrix, containPattern):
+		return OverlapBInsideA
+	case matrix[0] != 'F':
+		return OverlapPartial
+	case matchesPattern(matrix, disjointPattern):
+		return OverlapDisjoint
+	default:
+		return OverlapTouching
+	}
+}
+
+// matchesPattern returns true if a DE-9IM matrix matches a pattern of T, F,
+// *, 0, 1 or 2 - the way GEOS relatePattern does
+func matchesPattern(matrix, pattern string) bool {
+	if len(matrix) != 9 || len(pattern) != 9 {
+		return false
+	}
+
+	for i := 0; i < 9; i++ {
+		switch pattern[i] {
+		case '*':
+		case 'T':
+			if matrix[i] == 'F' {
+				return false
+			}
+		default:
+			if matrix[i] != pattern[i] {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,70 @@
+package gogeospace
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+func TestPolygonOverlap(t *testing.T) {
+	big := areaOf(t, box(0, 0, 2, 2))
+	shared := areaOf(t, box(1, 1, 2, 2))
+	unit := areaOf(t, box(0, 0, 1, 1))
+
+	tests := []struct {
+		name       string
+		a, b       point.Ring
+		class      OverlapClass
+		area       float64
+		percentOfA float64
+		percentOfB float64
+	}{
+		{name: "disjoint", a: box(0, 0, 1, 1), b: box(5, 5, 6, 6), class: OverlapDisjoint},
+		{name: "shared edge", a: box(0, 0, 1, 1), b: box(0, 1, 1, 2), class: OverlapTouching},
+		{name: "shared corner", a: box(0, 0, 1, 1), b: box(1, 1, 2, 2), class: OverlapTouching},
+		{name: "partial", a: box(0, 0, 2, 2), b: box(1, 1, 3, 3), class: OverlapPartial,
+			area: shared, percentOfA: shared / big * 100, percentOfB: shared / areaOf(t, box(1, 1, 3, 3)) * 100},
+		{name: "a inside b", a: box(0, 0, 1, 1), b: box(0, 0, 2, 2), class: OverlapAInsideB,
+			area: unit, percentOfA: 100, percentOfB: unit / big * 100},
+		{name: "b inside a", a: box(0, 0, 2, 2), b: box(1, 1, 2, 2), class: OverlapBInsideA,
+			area: shared, percentOfA: shared / big * 100, percentOfB: 100},
+		{name: "equal", a: box(0, 0, 1, 1), b: box(0, 0, 1, 1), class: OverlapEqual,
+			area: unit, percentOfA: 100, percentOfB: 100},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			overlap, err := PolygonOverlap(test.a, test.b, Options{Method: MethodHaversine})
+			if err != nil {
+				t.Fatalf("PolygonOverlap() error = %v", err)
+			}
+			if overlap.Class != test.class {
+				t.Errorf("Class = %v, want %v", overlap.Class, test.class)
+			}
+			if math.Abs(overlap.Area-test.area) > 1e-6*math.Max(test.area, 1) {
+				t.Errorf("Area = %v, want %v", overlap.Area, test.area)
+			}
+			if math.Abs(overlap.PercentOfA-test.percentOfA) > 1e-6 || math.Abs(overlap.PercentOfB-test.percentOfB) > 1e-6 {
+				t.Errorf("PercentOfA, PercentOfB = %v, %v, want %v, %v", overlap.PercentOfA, overlap.PercentOfB, test.percentOfA, test.percentOfB)
+			}
+			parts := 0
+			if test.area > 0 {
+				parts = 1
+			}
+			if len(overlap.Intersection) != parts {
+				t.Errorf("Intersection = %d polygons, want %d", len(overlap.Intersection), parts)
+			}
+		})
+	}
+
+	names := map[OverlapClass]string{
+		OverlapDisjoint: "disjoint", OverlapTouching: "touching", OverlapPartial: "partial overlap",
+		OverlapAInsideB: "a inside b", OverlapBInsideA: "b inside a", OverlapEqual: "equal", OverlapClass(42): "OverlapClass(42)",
+	}
+	for class, name := range names {
+		if got := class.String(); got != name {
+			t.Errorf("String() = %q, want %q", got, name)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package gogeospace
+
+import (
+	"fmt"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+// overlayOp is a GEOS set operation between two geometries
+type overlayOp func(a, b *geom.Geometry) (*geom.Geometry, error)
+
+// Union returns the area covered by a or b - adjacent or overlapping polygons
+// are merged into one
+// Params:
+// A, B rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func Union(a, b point.Polygonal, opts ...Options) (point.MultiPolygon, error) {
+	return overlay("union", (*geom.Geometry).Union, a, b, getOptions(opts))
+}
+
+// Difference returns the area of a not covered by b - such as a delivery zone
+// with an exclusion area subtracted
+// Params:
+// A, B rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func Difference(a, b point.Polygonal, opts ...Options) (point.MultiPolygon, error) {
+	return overlay("difference", (*geom.Geometry).Difference, a, b, getOptions(opts))
+}
+
+// SymDifference returns the area covered by exactly one of a or b
+// Params:
+// A, B rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func SymDifference(a, b point.Polygonal, opts ...Options) (point.MultiPolygon, error) {
+	return overlay("symmetric difference", (*geom.Geometry).SymDifference, a, b, getOptions(opts))
+}
+
+// UnionAll returns the area covered by any of the polygons - merges many
+// adjacent zones in one pass
+// Params:
+// Polygons rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func UnionAll(polygons []point.Polygonal, opts ...Options) (multiPolygon point.MultiPolygon, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	if len(polygons) == 0 {
+		return nil, nil
+	}
+
+	options := getOptions(opts)
+	f, err := newFrameForGeometry(options, polygonalGeometries(polygons)...)
+	if err != nil {
+		return nil, err
+	}
+
+	geoms := make([]*geom.Geometry, 0, len(polygons))
+	for _, polygon := range polygons {
+		geo, err := getGeosGeometry(polygon, f, options)
+		if err != nil {
+			return nil, err
+		}
+		geoms = append(geoms, geo)
+	}
+
+	all, err := geom.NewCollection(geom.GEOMETRYCOLLECTION, geoms...)
+	if err != nil {
+		return nil, fmt.Errorf("failed collecting polygons: %w", err)
+	}
+
+	union, err := all.UnaryUnion()
+	if err != nil {
+		return nil, fmt.Errorf("failed union of all polygons: %w", err)
+	}
+
+	collection, err := collectionFromGeos(union, f, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return simplifyResult(collection.Polygons, options)
+}
+
+func overlay(name string, op overlayOp, a, b point.Polygonal, opts Options) (multiPolygon point.MultiPolygon, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	geoA, geoB, f, err := getGeosGeometryPair(a, b, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := op(geoA, geoB)
+	if err != nil {
+		return nil, fmt.Errorf("failed %s of polygons: %w", name, err)
+	}
+
+	collection, err := collectionFromGeos(result, f, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return simplifyResult(collection.Polygons, opts)
+}
+
+// polygonalGeometries widens areal types to geometries
+func polygonalGeometries(polygons []point.Polygonal) []point.Geometry {
+	geometries := make([]point.Geometry, len(polygons))
+	for i, polygon := range polygons {
+		geometries[i] = polygon
+	}
+	return geometries
+}
@@ -0,0 +1,76 @@
+package gogeospace
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+// areaOf returns the spherical area of the polygonal input
+func areaOf(t *testing.T, polygonal point.Polygonal) float64 {
+	t.Helper()
+	area, err := Area(polygonal, MethodHaversine)
+	if err != nil {
+		t.Fatalf("Area() error = %v", err)
+	}
+	return area
+}
+
+func TestOverlays(t *testing.T) {
+	unit := areaOf(t, box(0, 0, 1, 1))
+	big := areaOf(t, box(0, 0, 2, 2))
+	shifted := areaOf(t, box(1, 1, 3, 3))
+	shared := areaOf(t, box(1, 1, 2, 2))
+
+	tests := []struct {
+		name     string
+		op       func(a, b point.Polygonal, opts ...Options) (point.MultiPolygon, error)
+		a, b     point.Polygonal
+		area     float64
+		polygons int
+		holes    int
+	}{
+		{name: "union of neighbours", op: Union, a: box(0, 0, 1, 1), b: box(0, 1, 1, 2), area: areaOf(t, box(0, 0, 1, 2)), polygons: 1},
+		{name: "union of overlapping squares", op: Union, a: box(0, 0, 2, 2), b: box(1, 1, 3, 3), area: big + shifted - shared, polygons: 1},
+		{name: "union of disjoint squares", op: Union, a: box(0, 0, 1, 1), b: box(5, 5, 6, 6), area: unit + areaOf(t, box(5, 5, 6, 6)), polygons: 2},
+		{name: "difference of overlapping squares", op: Difference, a: box(0, 0, 2, 2), b: box(1, 1, 3, 3), area: big - shared, polygons: 1},
+		{name: "difference punching a hole", op: Difference, a: box(0, 0, 3, 3), b: box(1, 1, 2, 2), area: areaOf(t, box(0, 0, 3, 3)) - shared, polygons: 1, holes: 1},
+		{name: "difference of covered square", op: Difference, a: box(1, 1, 2, 2), b: box(0, 0, 3, 3)},
+		{name: "difference of disjoint squares", op: Difference, a: box(0, 0, 1, 1), b: box(5, 5, 6, 6), area: unit, polygons: 1},
+		{name: "symmetric difference of overlapping squares", op: SymDifference, a: box(0, 0, 2, 2), b: box(1, 1, 3, 3), area: big + shifted - 2*shared, polygons: 2},
+		{name: "symmetric difference of equal squares", op: SymDifference, a: box(0, 0, 1, 1), b: box(0, 0, 1, 1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			multiPolygon, err := test.op(test.a, test.b)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if len(multiPolygon) != test.polygons {
+				t.Fatalf("polygons = %d, want %d", len(multiPolygon), test.polygons)
+			}
+			holes := 0
+			for _, polygon := range multiPolygon {
+				holes += len(polygon.Interiors)
+			}
+			if holes != test.holes {
+				t.Errorf("holes = %d, want %d", holes, test.holes)
+			}
+			if area := areaOf(t, multiPolygon); math.Abs(area-test.area) > 1e-6*math.Max(test.area, 1) {
+				t.Errorf("area = %v, want %v", area, test.area)
+			}
+		})
+	}
+
+	// Merging many neighbours at once matches merging them pairwise
+	row := []point.Polygonal{box(0, 0, 1, 1), box(0, 1, 1, 2), box(0, 2, 1, 3), box(0.5, 0.5, 0.6, 0.6)}
+	merged, err := UnionAll(row)
+	if err != nil {
+		t.Fatalf("UnionAll() error = %v", err)
+	}
+	if want := areaOf(t, box(0, 0, 1, 3)); len(merged) != 1 || math.Abs(areaOf(t, merged)-want) > 1e-6*want {
+		t.Errorf("UnionAll() = %d polygons of %v, want 1 of %v", len(merged), areaOf(t, merged), want)
+	}
+}
@@ -0,0 +1,14 @@
+package point
+
+// Geometry is implemented by every type geometry operations accept - points,
+// line strings, rings, polygons, multi polygons and geometry collections
+type Geometry interface {
+	isGeometry()
+}
+
+func (*Point) isGeometry()              {}
+func (LineString) isGeometry()          {}
+func (Ring) isGeometry()                {}
+func (*Polygon) isGeometry()            {}
+func (MultiPolygon) isGeometry()        {}
+func (*GeometryCollection) isGeometry() {}
@@ -0,0 +1,53 @@
+package point
+
+// Polygonal is implemented by every areal type - rings, polygons and multi
+// polygons can be passed to polygon operations interchangeably
+type Polygonal interface {
+	Geometry
+	Polygons() MultiPolygon
+}
+
+// Ring represents a polygon boundary as a single ring of points without holes
+type Ring []*Point
+
+// Polygons returns the ring as a multi polygon with one part
+func (r Ring) Polygons() MultiPolygon {
+	return MultiPolygon{{Exterior: r}}
+}
+
+// Polygon represents a polygon made of an exterior ring and zero or more
+// interior rings (holes)
+type Polygon struct {
+	Exterior  []*Point   `json:"exterior"`
+	Interiors [][]*Point `json:"interiors,omitempty"`
+}
+
+// Polygons returns the polygon as a multi polygon with one part
+func (p *Polygon) Polygons() MultiPolygon {
+	return MultiPolygon{p}
+}
+
+// MultiPolygon represents a collection of disjoint polygons, each with its own
+// exterior and interior rings
+type MultiPolygon []*Polygon
+
+// Polygons returns the multi polygon itself
+func (m MultiPolygon) Polygons() MultiPolygon {
+	return m
+}
+
+// LineString represents a sequence of connected points
+type LineString []*Point
+
+// GeometryCollection represents the mixed result of a polygon operation -
+// areal parts plus the lines and points left where shapes only touch
+type GeometryCollection struct {
+	Polygons    MultiPolygon `json:"polygons,omitempty"`
+	LineStrings []LineString `json:"lineStrings,omitempty"`
+	Points      []*Point     `json:"points,omitempty"`
+}
+
+// IsEmpty returns true if the collection has no polygons, lines or points
+func (c *GeometryCollection) IsEmpty() bool {
+	return c == nil || (len(c.Polygons) == 0 && len(c.LineStrings) == 0 && len(c.Points) == 0)
+}
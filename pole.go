@@ -0,0 +1,155 @@
+package gogeospace
+
+import (
+	"math"
+
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/utils"
+)
+
+const (
+	// POLE_TOLERANCE degrees two lngs of points on a pole closure may differ by
+	// whole turns and still be the same meridian
+	POLE_TOLERANCE = 1e-9
+	// POLE_WINDING_TOLERANCE degrees a ring around a pole may fall short of a
+	// whole turn from rounding
+	POLE_WINDING_TOLERANCE = 1e-6
+	// POLE_OVERSHOOT degrees past a pole the closure of a ring around it lies
+	// in the lat,lng plane so points at the pole are inside the ring
+	POLE_OVERSHOOT = 1.0
+	// PARALLEL_STEP degrees of lng between the points inserted along an edge
+	// that runs along a parallel
+	PARALLEL_STEP = 1.0
+)
+
+// poleRing returns the open ring winding once around a pole that a ring
+// encloses together with the lat of that pole - closures along a pole
+// parallel and the cut they hang off are removed and edges turning a whole
+// circle along a parallel are filled in. Rings that do not wind around a pole
+// are not pole rings
+func poleRing(ring []*point.Point) ([]*point.Point, float64, bool) {
+	ring = openRing(ring)
+
+	// Walk the edges from a vertex off the poles
+	start := -1
+	for i, p := range ring {
+		if p == nil {
+			return nil, 0, false
+		}
+		if start < 0 && math.Abs(p.Lat) != 90.0 {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil, 0, false
+	}
+
+	var walked []*point.Point
+	var winding float64
+	from, viaPole := ring[start], false
+	for i := 1; i <= len(ring); i++ {
+		to := ring[(start+i)%len(ring)]
+		if math.Abs(to.Lat) == 90.0 {
+			viaPole = true
+			continue
+		}
+
+		walked = append(walked, from)
+		// A closure along a pole is a cut - it does not turn around the pole
+		if !viaPole {
+			step := lngStep(from, to)
+			winding += step
+			if math.Abs(step) == 360.0 {
+				walked = append(walked, alongParallel(from, step)...)
+			}
+		}
+		from, viaPole = to, false
+	}
+
+	var raw []*point.Point
+	var lat float64
+	for _, p := range walked {
+		// Both ends of a cut lie on the same meridian a whole turn apart
+		if len(raw) > 0 && sameLocation(raw[len(raw)-1], p) {
+			continue
+		}
+		raw = append(raw, p)
+		lat += p.Lat
+	}
+	for len(raw) > 1 && sameLocation(raw[0], raw[len(raw)-1]) {
+		raw = raw[:len(raw)-1]
+	}
+
+	// Rings only touching a pole such as a half cap turn part of the way
+	if len(raw) < 3 || math.Abs(winding) < 360.0-POLE_WINDING_TOLERANCE {
+		return nil, 0, false
+	}
+
+	return raw, math.Copysign(90.0, lat), true
+}
+
+// lngStep returns the change of lng in degrees along the edge from a to b -
+// the shorter way around unless the edge turns a whole circle along a parallel
+// such as the side of a box from lng -180 to 180
+func lngStep(a, b *point.Point) float64 {
+	step := b.Lng - a.Lng
+	if math.Abs(step) == 360.0 && a.Lat == b.Lat {
+		return step
+	}
+	return math.Remainder(step, 360.0)
+}
+
+// alongParallel returns the points PARALLEL_STEP degrees apart strictly
+// between p and the point step degrees of lng along its parallel
+func alongParallel(p *point.Point, step float64) []*point.Point {
+	n := int(math.Ceil(math.Abs(step) / PARALLEL_STEP))
+
+	points := make([]*point.Point, 0, n)
+	for i := 1; i < n; i++ {
+		lng := p.Lng + step*float64(i)/float64(n)
+		points = append(points, &point.Point{Lat: p.Lat, Lng: utils.NormalizeLongitude(lng)})
+	}
+	return points
+}
+
+// fullWidth returns true if a ring not around a pole has an edge turning a
+// whole circle along a parallel - such as a band around the globe from lng
+// -180 to 180
+func fullWidth(ring []*point.Point) bool {
+	if _, _, ok := poleRing(ring); ok {
+		return false
+	}
+
+	ring = openRing(ring)
+	for i, p := range ring {
+		if math.Abs(lngStep(p, ring[(i+1)%len(ring)])) == 360.0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fillParallels copies an open ring with points inserted along the edges that
+// run along a parallel off the poles - such edges follow the parallel like
+// the straight lat,lng edges of overlay results instead of the great circle
+// between their ends
+func fillParallels(ring []*point.Point) []*point.Point {
+	filled := make([]*point.Point, 0, len(ring))
+	for i, p := range ring {
+		filled = append(filled, p)
+		next := ring[(i+1)%len(ring)]
+		if p.Lat != next.Lat || math.Abs(p.Lat) == 90.0 {
+			continue
+		}
+		if step := lngStep(p, next); math.Abs(step) > PARALLEL_STEP {
+			filled = append(filled, alongParallel(p, step)...)
+		}
+	}
+	return filled
+}
+
+// sameLocation returns true if both points lie at the same lat and on the same
+// meridian
+func sameLocation(a, b *point.Point) bool {
+	return a.Lat == b.Lat && math.Abs(math.Remainder(a.Lng-b.Lng, 360.0)) < POLE_TOLERANCE
+}
@@ -0,0 +1,114 @@
+package gogeospace
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/vincenty"
+)
+
+func TestIntersectPolygonWithDiscReachingBothPoles(t *testing.T) {
+	generators := map[string]DiscGenerator{"haversine": haversine.Generator{}, "vincenty": vincenty.Generator{}}
+
+	for name, generator := range generators {
+		t.Run(name, func(t *testing.T) {
+			_, err := IntersectPolygonWithDisc(box(-10, -10, 10, 10), &point.Point{}, 12000000, Options{Generator: generator})
+			if !errors.Is(err, ErrInvalidDisc) {
+				t.Errorf("IntersectPolygonWithDisc() error = %v, want %v", err, ErrInvalidDisc)
+			}
+		})
+	}
+}
+
+func TestPolarDiscs(t *testing.T) {
+	radius := 500000.0
+	discArea := 2 * math.Pi * haversine.EARTH_RADIUS_CONSTANT * haversine.EARTH_RADIUS_CONSTANT * (1 - math.Cos(radius/haversine.EARTH_RADIUS_CONSTANT))
+
+	for _, pole := range []float64{90, -90} {
+		sign := math.Copysign(1, pole)
+		center := &point.Point{Lat: 89 * sign, Lng: 45}
+		polarDisc := point.Ring(haversine.CreateDisc(center.Lat, center.Lng, radius))
+		capBox := box(80*sign, -180, 90*sign, 180)
+
+		t.Run(fmt.Sprintf("pole %v", pole), func(t *testing.T) {
+			area, err := Area(polarDisc, MethodHaversine)
+			if err != nil || math.Abs(area-discArea) > 1e-3*discArea {
+				t.Errorf("Area() = %v, %v, want %v", area, err, discArea)
+			}
+
+			containment := []struct {
+				location *point.Point
+				contains bool
+			}{
+				{location: &point.Point{Lat: pole}, contains: true},
+				{location: &point.Point{Lat: 88.5 * sign, Lng: -135}, contains: true},
+				{location: &point.Point{Lat: 85 * sign, Lng: -135}, contains: false},
+				{location: &point.Point{Lat: 85 * sign, Lng: 45}, contains: true},
+			}
+			for _, test := range containment {
+				contains, err := Contains(polarDisc, test.location)
+				if err != nil || contains != test.contains {
+					t.Errorf("Contains(%v, %v) = %v, %v, want %v", test.location.Lat, test.location.Lng, contains, err, test.contains)
+				}
+			}
+
+			collection, err := IntersectPolygonWithDisc(capBox, center, radius, Options{Generator: haversine.Generator{}, AreaOnly: true})
+			if err != nil {
+				t.Fatalf("IntersectPolygonWithDisc() error = %v", err)
+			}
+			area, err = Area(collection.Polygons, MethodHaversine)
+			if err != nil || math.Abs(area-discArea) > 1e-3*discArea {
+				t.Errorf("IntersectPolygonWithDisc() area = %v, %v, want %v", area, err, discArea)
+			}
+
+			collection, err = IntersectPolygonWithDisc(box(70*sign, 0, 90*sign, 90), center, radius, Options{Generator: haversine.Generator{}, AreaOnly: true})
+			if err != nil {
+				t.Fatalf("IntersectPolygonWithDisc() error = %v", err)
+			}
+			area, err = Area(collection.Polygons, MethodHaversine)
+			if err != nil || len(collection.Polygons) != 1 || area <= discArea/4 || area >= discArea/2 {
+				t.Errorf("IntersectPolygonWithDisc() = %d polygons of %v, %v, want one of a quarter to a half of %v",
+					len(collection.Polygons), area, err, discArea)
+			}
+		})
+	}
+}
+
+func TestMeasureAlongParallels(t *testing.T) {
+	radius := haversine.EARTH_RADIUS_CONSTANT
+	capArea := 2 * math.Pi * radius * radius * (1 - math.Sin(80*math.Pi/180))
+	boxArea := radius * radius * 30 * math.Pi / 180 * (math.Sin(20*math.Pi/180) - math.Sin(10*math.Pi/180))
+
+	tests := []struct {
+		name      string
+		ring      point.Ring
+		area      float64
+		perimeter float64
+	}{
+		{name: "half cap", ring: box(80, -90, 90, 90), area: capArea / 2},
+		{name: "part of cap", ring: box(80, 0, 90, 128), area: capArea * 128 / 360,
+			perimeter: radius * math.Pi / 180 * (128*math.Cos(80*math.Pi/180) + 20)},
+		{name: "box", ring: box(10, 0, 20, 30), area: boxArea},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			area, err := Area(test.ring, MethodHaversine)
+			if err != nil || math.Abs(area-test.area) > 1e-3*test.area {
+				t.Errorf("Area() = %v, %v, want %v", area, err, test.area)
+			}
+
+			if test.perimeter == 0 {
+				return
+			}
+			perimeter, err := Perimeter(test.ring, MethodHaversine)
+			if err != nil || math.Abs(perimeter-test.perimeter) > 1e-3*test.perimeter {
+				t.Errorf("Perimeter() = %v, %v, want %v", perimeter, err, test.perimeter)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package gogeospace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+// predicateOp is a GEOS spatial predicate between two geometries
+type predicateOp func(a, b *geom.Geometry) (bool, error)
+
+// Intersects returns true if a and b share at least one point
+// Params:
+// A, B points, line strings, rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func Intersects(a, b point.Geometry, opts ...Options) (bool, error) {
+	return predicate("intersects", (*geom.Geometry).Intersects, a, b, getOptions(opts))
+}
+
+// Disjoint returns true if a and b share no point
+// Params:
+// A, B points, line strings, rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func Disjoint(a, b point.Geometry, opts ...Options) (bool, error) {
+	return predicate("disjoint", (*geom.Geometry).Disjoint, a, b, getOptions(opts))
+}
+
+// Contains returns true if no point of b lies outside a and the interiors of
+// a and b share at least one point - a boundary point is not contained
+// Params:
+// A, B points, line strings, rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func Contains(a, b point.Geometry, opts ...Options) (bool, error) {
+	return predicate("contains", (*geom.Geometry).Contains, a, b, getOptions(opts))
+}
+
+// Within returns true if a is contained by b
+// Params:
+// A, B points, line strings, rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func Within(a, b point.Geometry, opts ...Options) (bool, error) {
+	return predicate("within", (*geom.Geometry).Within, a, b, getOptions(opts))
+}
+
+// Covers returns true if no point of b lies outside a - unlike Contains a
+// point on the boundary of a is covered
+// Params:
+// A, B points, line strings, rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func Covers(a, b point.Geometry, opts ...Options) (bool, error) {
+	return predicate("covers", (*geom.Geometry).Covers, a, b, getOptions(opts))
+}
+
+// CoveredBy returns true if a is covered by b
+// Params:
+// A, B points, line strings, rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func CoveredBy(a, b point.Geometry, opts ...Options) (bool, error) {
+	return predicate("covered by", (*geom.Geometry).CoveredBy, a, b, getOptions(opts))
+}
+
+// Touches returns true if a and b share boundary points but no interior points
+// Params:
+// A, B points, line strings, rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func Touches(a, b point.Geometry, opts ...Options) (bool, error) {
+	return predicate("touches", (*geom.Geometry).Touches, a, b, getOptions(opts))
+}
+
+// Crosses returns true if a and b share some interior points of a lower
+// dimension than the higher of the two - such as a line passing through a polygon
+// Params:
+// A, B points, line strings, rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func Crosses(a, b point.Geometry, opts ...Options) (bool, error) {
+	return predicate("crosses", (*geom.Geometry).Crosses, a, b, getOptions(opts))
+}
+
+// Overlaps returns true if a and b of the same dimension share some but not
+// all interior points
+// Params:
+// A, B points, line strings, rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func Overlaps(a, b point.Geometry, opts ...Options) (bool, error) {
+	return predicate("overlaps", (*geom.Geometry).Overlaps, a, b, getOptions(opts))
+}
+
+// Relate returns the DE-9IM intersection matrix of a and b such as 212101212
+// Params:
+// A, B points, line strings, rings, polygons or multi polygons of lat,lng in degrees
+// Opts optional settings such as the projection centered on the inputs
+func Relate(a, b point.Geometry, opts ...Options) (matrix string, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	geoA, geoB, _, err := getGeosGeometryPair(a, b, getOptions(opts))
+	if err != nil {
+		return "", err
+	}
+
+	matrix, err = geoA.Relate(geoB)
+	if err != nil {
+		return "", fmt.Errorf("failed relating geometries: %w", err)
+	}
+
+	return matrix, nil
+}
+
+// RelatePattern returns true if the DE-9IM intersection matrix of a and b
+// matches the pattern such as T*F**F***
+// Params:
+// A, B points, line strings, rings, polygons or multi polygons of lat,lng in degrees
+// Pattern nine characters of T, F, *, 0, 1 or 2
+// Opts optional settings such as the projection centered on the inputs
+func RelatePattern(a, b point.Geometry, pattern string, opts ...Options) (bool, error) {
+	return predicate("relate pattern", func(geoA, geoB *geom.Geometry) (bool, error) {
+		return geoA.RelatePat(geoB, pattern)
+	}, a, b, getOptions(opts))
+}
+
+func predicate(name string, op predicateOp, a, b point.Geometry, opts Options) (bool, error) {
+	return predicateContext(context.Background(), name, op, a, b, opts)
+}
+
+func predicateContext(ctx context.Context, name string, op predicateOp, a, b point.Geometry, opts Options) (result bool, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	ctx, cancel := operationContext(ctx, opts)
+	defer cancel()
+
+	if err := checkVertexBudget(opts, a, b); err != nil {
+		return false, err
+	}
+
+	geoA, geoB, _, err := getGeosGeometryPair(a, b, opts)
+	if err != nil {
+		return false, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	result, err = op(geoA, geoB)
+	if err != nil {
+		return false, fmt.Errorf("failed %s predicate: %w", name, err)
+	}
+
+	return result, nil
+}
+
+// getGeosGeometryPair builds both GEOS geometries in one frame centered on both
+func getGeosGeometryPair(a, b point.Geometry, opts Options) (*geom.Geometry, *geom.Geometry, frame, error) {
+	f, err := newFrameForGeometry(opts, a, b)
+	if err != nil {
+		return nil, nil, f, err
+	}
+
+	geoA, err := getGeosGeometry(a, f, opts)
+	if err != nil {
+		return nil, nil, f, err
+	}
+
+	geoB, err := getGeosGeometry(b, f, opts)
+	if err != nil {
+		return nil, nil, f, err
+	}
+
+	return geoA, geoB, f, nil
+}
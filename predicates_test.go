@@ -0,0 +1,81 @@
+package gogeospace
+
+import (
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+func TestPredicates(t *testing.T) {
+	type predicateFunc func(a, b point.Geometry, opts ...Options) (bool, error)
+	predicates := map[string]predicateFunc{
+		"intersects": Intersects,
+		"disjoint":   Disjoint,
+		"contains":   Contains,
+		"within":     Within,
+		"covers":     Covers,
+		"coveredBy":  CoveredBy,
+		"touches":    Touches,
+		"crosses":    Crosses,
+		"overlaps":   Overlaps,
+	}
+
+	tests := []struct {
+		name   string
+		a, b   point.Geometry
+		matrix string
+		// holds lists the predicates that are true - every other one is false
+		holds []string
+	}{
+		{name: "overlapping squares", a: box(0, 0, 2, 2), b: box(1, 1, 3, 3), matrix: "212101212",
+			holds: []string{"intersects", "overlaps"}},
+		{name: "nested squares", a: box(0, 0, 4, 4), b: box(1, 1, 2, 2), matrix: "212FF1FF2",
+			holds: []string{"intersects", "contains", "covers"}},
+		{name: "edge sharing squares", a: box(0, 0, 1, 1), b: box(0, 1, 1, 2), matrix: "FF2F11212",
+			holds: []string{"intersects", "touches"}},
+		{name: "disjoint squares", a: box(0, 0, 1, 1), b: box(5, 5, 6, 6), matrix: "FF2FF1212",
+			holds: []string{"disjoint"}},
+		{name: "equal squares", a: box(0, 0, 1, 1), b: box(0, 0, 1, 1), matrix: "2FFF1FFF2",
+			holds: []string{"intersects", "contains", "within", "covers", "coveredBy"}},
+		{name: "point inside", a: box(0, 0, 2, 2), b: &point.Point{Lat: 1, Lng: 1}, matrix: "0F2FF1FF2",
+			holds: []string{"intersects", "contains", "covers"}},
+		{name: "point on boundary", a: box(0, 0, 2, 2), b: &point.Point{Lat: 0, Lng: 1}, matrix: "FF20F1FF2",
+			holds: []string{"intersects", "covers", "touches"}},
+		{name: "point outside", a: &point.Point{Lat: 5, Lng: 5}, b: box(0, 0, 2, 2), matrix: "FF0FFF212",
+			holds: []string{"disjoint"}},
+		{name: "line through square", a: box(0, 0, 2, 2), b: point.LineString{{Lat: 1, Lng: -1}, {Lat: 1, Lng: 3}}, matrix: "1F20F1102",
+			holds: []string{"intersects", "crosses"}},
+		{name: "line inside square", a: box(0, 0, 2, 2), b: point.LineString{{Lat: 0.5, Lng: 0.5}, {Lat: 1.5, Lng: 1.5}}, matrix: "102FF1FF2",
+			holds: []string{"intersects", "contains", "covers"}},
+		{name: "crossing lines", a: point.LineString{{Lat: 0, Lng: 0}, {Lat: 2, Lng: 2}}, b: point.LineString{{Lat: 0, Lng: 2}, {Lat: 2, Lng: 0}}, matrix: "0F1FF0102",
+			holds: []string{"intersects", "crosses"}},
+		{name: "square in hole", a: &point.Polygon{Exterior: box(0, 0, 4, 4), Interiors: [][]*point.Point{box(1, 1, 3, 3)}}, b: box(1.5, 1.5, 2.5, 2.5), matrix: "FF2FF1212",
+			holds: []string{"disjoint"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			matrix, err := Relate(test.a, test.b)
+			if err != nil || matrix != test.matrix {
+				t.Errorf("Relate() = %v, %v, want %v", matrix, err, test.matrix)
+			}
+			if ok, err := RelatePattern(test.a, test.b, test.matrix); err != nil || !ok {
+				t.Errorf("RelatePattern(%s) = %v, %v, want true", test.matrix, ok, err)
+			}
+
+			holds := map[string]bool{}
+			for _, name := range test.holds {
+				holds[name] = true
+			}
+			for name, fn := range predicates {
+				got, err := fn(test.a, test.b)
+				if err != nil {
+					t.Fatalf("%s error = %v", name, err)
+				}
+				if got != holds[name] {
+					t.Errorf("%s = %v, want %v", name, got, holds[name])
+				}
+			}
+		})
+	}
+}
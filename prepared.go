@@ -0,0 +1,169 @@
+package gogeospace
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+// PreparedPolygon is a boundary polygon built once from coordinates and
+// indexed by GEOS for many point-in-polygon and intersect queries against it.
+// Safe to share across goroutines - call Close when done with it
+type PreparedPolygon struct {
+	mu       sync.RWMutex
+	opts     Options
+	frame    frame
+	geometry *geom.Geometry
+	prepared *geom.PGeometry
+}
+
+// NewPreparedPolygon builds a prepared polygon from coordinates
+// Params:
+// Coordinates forming a polygon slice of lat,lng in degrees
+// Opts optional settings such as the projection centered on the polygon, the
+// disc generator and repair - used by every query
+func NewPreparedPolygon(coordinates []*point.Point, opts ...Options) (preparedPolygon *PreparedPolygon, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	options := getOptions(opts)
+	f, err := newFrameForPoints(options, coordinates)
+	if err != nil {
+		return nil, err
+	}
+
+	geometry, err := getGeosPolygon(coordinates, f, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PreparedPolygon{
+		opts:     options,
+		frame:    f,
+		geometry: geometry,
+		prepared: geometry.Prepare(),
+	}, nil
+}
+
+// ContainsPoint returns true if the point lies inside or on the boundary of
+// the polygon
+func (p *PreparedPolygon) ContainsPoint(location *point.Point) (contains bool, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.prepared == nil {
+		return false, ErrClosed
+	}
+
+	if err := p.frame.checkDomain([]*point.Point{location}); err != nil {
+		return false, err
+	}
+
+	geo, err := getGeosGeometry(location, p.frame, p.opts)
+	if err != nil {
+		return false, err
+	}
+
+	contains, err = p.prepared.Covers(geo)
+	if err != nil {
+		return false, fmt.Errorf("failed prepared covers predicate: %w", err)
+	}
+
+	return contains, nil
+}
+
+// IntersectsPolygon returns true if the polygon and the passed in coordinates
+// share at least one point
+func (p *PreparedPolygon) IntersectsPolygon(coordinates []*point.Point) (intersects bool, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.prepared == nil {
+		return false, ErrClosed
+	}
+
+	if err := p.frame.checkDomain(coordinates); err != nil {
+		return false, err
+	}
+
+	geo, err := getGeosPolygon(coordinates, p.frame, p.opts)
+	if err != nil {
+		return false, err
+	}
+
+	intersects, err = p.prepared.Intersects(geo)
+	if err != nil {
+		return false, fmt.Errorf("failed prepared intersects predicate: %w", err)
+	}
+
+	return intersects, nil
+}
+
+// IntersectDisc returns the intersection of the polygon and a disc created by
+// the prepared options generator around the passed in center point and radius
+// like IntersectPolygonWithDisc - the projection stays centered on the polygon
+func (p *PreparedPolygon) IntersectDisc(center *point.Point, radius float64) (*point.GeometryCollection, error) {
+	return p.IntersectDiscContext(context.Background(), center, radius)
+}
+
+// IntersectDiscContext is IntersectDisc giving up with ctx.Err() between disc
+// generation, geometry building and the intersection once the context is done
+func (p *PreparedPolygon) IntersectDiscContext(ctx context.Context, center *point.Point, radius float64) (collection *point.GeometryCollection, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	ctx, cancel := operationContext(ctx, p.opts)
+	defer cancel()
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.prepared == nil {
+		return nil, ErrClosed
+	}
+
+	if err := checkDisc(center, radius); err != nil {
+		return nil, err
+	}
+
+	polyCoordinates, err := createDisc(center, radius, p.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVertexBudget(p.opts, point.Ring(polyCoordinates)); err != nil {
+		return nil, err
+	}
+
+	if err := p.frame.checkDomain(polyCoordinates); err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return processPolyCoordinates(ctx, polyCoordinates, p.geometry, p.frame, p.opts)
+}
+
+// Close releases the GEOS geometries once no query is running - later queries
+// fail with ErrClosed
+func (p *PreparedPolygon) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// GEOS memory is freed by the geometry finalizers once unreferenced
+	p.geometry = nil
+	p.prepared = nil
+
+	return nil
+}
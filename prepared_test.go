@@ -0,0 +1,81 @@
+package gogeospace
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+func TestPreparedPolygon(t *testing.T) {
+	prepared, err := NewPreparedPolygon(box(0, 0, 2, 2))
+	if err != nil {
+		t.Fatalf("NewPreparedPolygon() error = %v", err)
+	}
+
+	points := []struct {
+		name     string
+		location *point.Point
+		contains bool
+	}{
+		{name: "inside", location: &point.Point{Lat: 1, Lng: 1}, contains: true},
+		{name: "on an edge", location: &point.Point{Lat: 0, Lng: 1}, contains: true},
+		{name: "on a corner", location: &point.Point{Lat: 2, Lng: 2}, contains: true},
+		{name: "outside", location: &point.Point{Lat: 3, Lng: 1}},
+	}
+	for _, test := range points {
+		contains, err := prepared.ContainsPoint(test.location)
+		if err != nil || contains != test.contains {
+			t.Errorf("ContainsPoint(%s) = %v, %v, want %v", test.name, contains, err, test.contains)
+		}
+	}
+
+	polygons := []struct {
+		name       string
+		ring       point.Ring
+		intersects bool
+	}{
+		{name: "overlapping", ring: box(1, 1, 3, 3), intersects: true},
+		{name: "touching", ring: box(2, 0, 3, 1), intersects: true},
+		{name: "inside", ring: box(0.5, 0.5, 1, 1), intersects: true},
+		{name: "around", ring: box(-1, -1, 3, 3), intersects: true},
+		{name: "disjoint", ring: box(5, 5, 6, 6)},
+	}
+	for _, test := range polygons {
+		intersects, err := prepared.IntersectsPolygon(test.ring)
+		if err != nil || intersects != test.intersects {
+			t.Errorf("IntersectsPolygon(%s) = %v, %v, want %v", test.name, intersects, err, test.intersects)
+		}
+	}
+
+	// Discs match the one off intersection
+	center := &point.Point{Lat: 2, Lng: 1}
+	collection, err := prepared.IntersectDisc(center, 50000)
+	if err != nil {
+		t.Fatalf("IntersectDisc() error = %v", err)
+	}
+	want, err := IntersectPolygonWithDisc(box(0, 0, 2, 2), center, 50000, Options{})
+	if err != nil {
+		t.Fatalf("IntersectPolygonWithDisc() error = %v", err)
+	}
+	if !reflect.DeepEqual(collection, want) {
+		t.Errorf("IntersectDisc() differs from IntersectPolygonWithDisc()")
+	}
+
+	if err := prepared.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := prepared.ContainsPoint(center); !errors.Is(err, ErrClosed) {
+		t.Errorf("ContainsPoint() after Close error = %v, want %v", err, ErrClosed)
+	}
+	if _, err := prepared.IntersectsPolygon(box(1, 1, 3, 3)); !errors.Is(err, ErrClosed) {
+		t.Errorf("IntersectsPolygon() after Close error = %v, want %v", err, ErrClosed)
+	}
+	if _, err := prepared.IntersectDisc(center, 50000); !errors.Is(err, ErrClosed) {
+		t.Errorf("IntersectDisc() after Close error = %v, want %v", err, ErrClosed)
+	}
+	if err := prepared.Close(); err != nil {
+		t.Errorf("Close() twice error = %v", err)
+	}
+}
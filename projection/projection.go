@@ -0,0 +1,162 @@
+package projection
+
+import (
+	"math"
+
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/utils"
+)
+
+// Projection converts between lat, lng in degrees and planar x, y in meters
+// on a local plane tangent to the Earth at the projection center
+type Projection interface {
+	Forward(lat, lng float64) (x, y float64)
+	Inverse(x, y float64) (lat, lng float64)
+}
+
+// AzimuthalEquidistant is a spherical azimuthal equidistant projection -
+// distances and bearings from the center are preserved
+type AzimuthalEquidistant struct {
+	lat0Rad, lng0Rad float64
+}
+
+// NewAzimuthalEquidistant creates an azimuthal equidistant projection centered
+// at lat0, lng0 in degrees
+func NewAzimuthalEquidistant(lat0, lng0 float64) *AzimuthalEquidistant {
+	return &AzimuthalEquidistant{
+		lat0Rad: utils.DegreesToRadians(lat0),
+		lng0Rad: utils.DegreesToRadians(lng0),
+	}
+}
+
+// Forward projects lat, lng in degrees to x, y in meters
+func (p *AzimuthalEquidistant) Forward(lat, lng float64) (float64, float64) {
+	latRad := utils.DegreesToRadians(lat)
+	deltaLngRad := utils.DegreesToRadians(lng) - p.lng0Rad
+
+	// angular distance from the center
+	c := centralAngle(p.lat0Rad, latRad, deltaLngRad)
+	k := 1.0
+	if c != 0 {
+		k = c / math.Sin(c)
+	}
+
+	return forward(p.lat0Rad, latRad, deltaLngRad, k)
+}
+
+// Inverse unprojects x, y in meters to lat, lng in degrees
+func (p *AzimuthalEquidistant) Inverse(x, y float64) (float64, float64) {
+	rho := math.Hypot(x, y)
+	c := rho / haversine.EARTH_RADIUS_CONSTANT
+
+	return inverse(p.lat0Rad, p.lng0Rad, x, y, rho, c)
+}
+
+// LambertAzimuthalEqualArea is a spherical Lambert azimuthal equal-area
+// projection - areas are preserved
+type LambertAzimuthalEqualArea struct {
+	lat0Rad, lng0Rad float64
+}
+
+// NewLambertAzimuthalEqualArea creates a Lambert azimuthal equal-area
+// projection centered at lat0, lng0 in degrees
+func NewLambertAzimuthalEqualArea(lat0, lng0 float64) *LambertAzimuthalEqualArea {
+	return &LambertAzimuthalEqualArea{
+		lat0Rad: utils.DegreesToRadians(lat0),
+		lng0Rad: utils.DegreesToRadians(lng0),
+	}
+}
+
+// Forward projects lat, lng in degrees to x, y in meters
+func (p *LambertAzimuthalEqualArea) Forward(lat, lng float64) (float64, float64) {
+	latRad := utils.DegreesToRadians(lat)
+	deltaLngRad := utils.DegreesToRadians(lng) - p.lng0Rad
+
+	// chord length scale - 2 * sin(c / 2) / sin(c) simplified
+	c := centralAngle(p.lat0Rad, latRad, deltaLngRad)
+	k := 1.0
+	if c != 0 {
+		k = 1.0 / math.Cos(c/2.0)
+	}
+
+	return forward(p.lat0Rad, latRad, deltaLngRad, k)
+}
+
+// Inverse unprojects x, y in meters to lat, lng in degrees
+func (p *LambertAzimuthalEqualArea) Inverse(x, y float64) (float64, float64) {
+	rho := math.Hypot(x, y)
+	c := 2.0 * math.Asin(math.Min(1.0, rho/(2.0*haversine.EARTH_RADIUS_CONSTANT)))
+
+	return inverse(p.lat0Rad, p.lng0Rad, x, y, rho, c)
+}
+
+// Gnomonic is a spherical gnomonic projection - great circles are straight
+// lines so polygon edges follow the geodesic. Only the hemisphere around the
+// center can be projected
+type Gnomonic struct {
+	lat0Rad, lng0Rad float64
+}
+
+// NewGnomonic creates a gnomonic projection centered at lat0, lng0 in degrees
+func NewGnomonic(lat0, lng0 float64) *Gnomonic {
+	return &Gnomonic{
+		lat0Rad: utils.DegreesToRadians(lat0),
+		lng0Rad: utils.DegreesToRadians(lng0),
+	}
+}
+
+// Forward projects lat, lng in degrees to x, y in meters - points 90 degrees
+// or more from the center project to infinity
+func (p *Gnomonic) Forward(lat, lng float64) (float64, float64) {
+	latRad := utils.DegreesToRadians(lat)
+	deltaLngRad := utils.DegreesToRadians(lng) - p.lng0Rad
+
+	// tangent plane scale - tan(c) / sin(c) simplified
+	c := centralAngle(p.lat0Rad, latRad, deltaLngRad)
+	k := 1.0 / math.Cos(c)
+
+	return forward(p.lat0Rad, latRad, deltaLngRad, k)
+}
+
+// Inverse unprojects x, y in meters to lat, lng in degrees
+func (p *Gnomonic) Inverse(x, y float64) (float64, float64) {
+	rho := math.Hypot(x, y)
+	c := math.Atan(rho / haversine.EARTH_RADIUS_CONSTANT)
+
+	return inverse(p.lat0Rad, p.lng0Rad, x, y, rho, c)
+}
+
+// centralAngle is the great circle angle in radians between the center and a
+// point - haversine form stays accurate for small distances
+func centralAngle(lat0Rad, latRad, deltaLngRad float64) float64 {
+	sinDeltaLat := math.Sin((latRad - lat0Rad) / 2.0)
+	sinDeltaLng := math.Sin(deltaLngRad / 2.0)
+	h := sinDeltaLat*sinDeltaLat + math.Cos(lat0Rad)*math.Cos(latRad)*sinDeltaLng*sinDeltaLng
+
+	return 2.0 * math.Asin(math.Min(1.0, math.Sqrt(h)))
+}
+
+// forward is shared by the azimuthal projections which only differ in the
+// radial scale factor k
+func forward(lat0Rad, latRad, deltaLngRad, k float64) (float64, float64) {
+	x := haversine.EARTH_RADIUS_CONSTANT * k * math.Cos(latRad) * math.Sin(deltaLngRad)
+	y := haversine.EARTH_RADIUS_CONSTANT * k * (math.Cos(lat0Rad)*math.Sin(latRad) - math.Sin(lat0Rad)*math.Cos(latRad)*math.Cos(deltaLngRad))
+
+	return x, y
+}
+
+// inverse is shared by the azimuthal projections which only differ in how the
+// angular distance c is derived from the planar distance rho
+func inverse(lat0Rad, lng0Rad, x, y, rho, c float64) (float64, float64) {
+	if rho == 0 {
+		return utils.RadToDegrees(lat0Rad), utils.RadToDegrees(lng0Rad)
+	}
+
+	sinC := math.Sin(c)
+	cosC := math.Cos(c)
+
+	latRad := math.Asin(math.Max(-1.0, math.Min(1.0, cosC*math.Sin(lat0Rad)+y*sinC*math.Cos(lat0Rad)/rho)))
+	lngRad := lng0Rad + math.Atan2(x*sinC, rho*math.Cos(lat0Rad)*cosC-y*math.Sin(lat0Rad)*sinC)
+
+	return utils.RadToDegrees(latRad), utils.RadToDegrees(lngRad)
+}
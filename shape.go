@@ -0,0 +1,85 @@
+package gogeospace
+
+import (
+	"context"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+// IntersectPolygonWithShape returns the intersection of an individual polygon
+// and a shape such as a sector, annulus or ellipse from the haversine or
+// vincenty generators - one polygon per disjoint part, each with its exterior
+// ring and interior rings (holes), plus the lines and points left where the
+// shape only touches the polygon unless only areal parts are kept
+// Params:
+// Polygonal ring, polygon with holes or multi polygon of lat,lng in degrees
+// Shape ring, polygon with holes or multi polygon of lat,lng in degrees
+// Opts settings such as the projection centered on the shape
+func IntersectPolygonWithShape(
+	polygonal point.Polygonal,
+	shape point.Polygonal,
+	opts Options) (*point.GeometryCollection, error) {
+
+	return IntersectPolygonWithShapeContext(context.Background(), polygonal, shape, opts)
+}
+
+// IntersectPolygonWithShapeContext is IntersectPolygonWithShape giving up
+// with ctx.Err() between geometry building and the intersection once the
+// context is done
+func IntersectPolygonWithShapeContext(
+	ctx context.Context,
+	polygonal point.Polygonal,
+	shape point.Polygonal,
+	opts Options) (collection *point.GeometryCollection, err error) {
+
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	ctx, cancel := operationContext(ctx, opts)
+	defer cancel()
+
+	if polygonal == nil {
+		return nil, &PolygonError{Reason: "nil polygon", Err: ErrInvalidPolygon}
+	}
+
+	if shape == nil {
+		return nil, &PolygonError{Reason: "nil shape", Err: ErrInvalidPolygon}
+	}
+
+	if err := checkVertexBudget(opts, shape, polygonal); err != nil {
+		return nil, err
+	}
+
+	f, err := newFrameForGeometry(opts, shape)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.checkDomain(pointsOf(polygonal)...); err != nil {
+		return nil, err
+	}
+
+	dotPolygon, err := getGeosGeometry(polygonal, f, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if dotPolygon == nil {
+		return nil, &PolygonError{Reason: "nil geometric poly shape from incoming boundary coordinates", Err: ErrInvalidPolygon}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	shapeGeo, err := getGeosGeometry(shape, f, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return intersectGeos(ctx, "shape", dotPolygon, shapeGeo, f, opts)
+}
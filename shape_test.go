@@ -0,0 +1,83 @@
+package gogeospace
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/vincenty"
+)
+
+func TestInvalidShapes(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+
+	tests := []struct {
+		name  string
+		shape func() point.Polygonal
+	}{
+		{name: "haversine sector zero radius", shape: func() point.Polygonal { return point.Ring(haversine.CreateSector(0, 0, 0, 0, 90)) }},
+		{name: "haversine sector NaN bearing", shape: func() point.Polygonal { return point.Ring(haversine.CreateSector(0, 0, 1000, nan, 90)) }},
+		{name: "vincenty sector infinite radius", shape: func() point.Polygonal { return point.Ring(vincenty.CreateSector(0, 0, inf, 0, 90)) }},
+		{name: "vincenty sector infinite bearing", shape: func() point.Polygonal { return point.Ring(vincenty.CreateSector(0, 0, 1000, 0, inf)) }},
+		{name: "haversine ellipse negative axis", shape: func() point.Polygonal { return point.Ring(haversine.CreateEllipse(0, 0, 1000, -10, 0)) }},
+		{name: "vincenty ellipse NaN axis", shape: func() point.Polygonal { return point.Ring(vincenty.CreateEllipse(0, 0, nan, 1000, 0)) }},
+		{name: "vincenty ellipse NaN center", shape: func() point.Polygonal { return point.Ring(vincenty.CreateEllipse(nan, 0, 2000, 1000, 0)) }},
+		{name: "haversine disc NaN radius", shape: func() point.Polygonal { return point.Ring(haversine.CreateDisc(0, 0, nan)) }},
+		{name: "vincenty disc lat past pole", shape: func() point.Polygonal { return point.Ring(vincenty.CreateDisc(91, 0, 1000)) }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shape := test.shape()
+			if shape.(point.Ring) != nil {
+				t.Fatalf("shape = %d points, want nil", len(shape.(point.Ring)))
+			}
+
+			_, err := IntersectPolygonWithShape(box(-1, -1, 1, 1), shape, Options{})
+			if !errors.Is(err, ErrInvalidPolygon) && !errors.Is(err, ErrTooFewPoints) {
+				t.Errorf("IntersectPolygonWithShape() error = %v, want %v", err, ErrInvalidPolygon)
+			}
+		})
+	}
+
+	annuli := map[string]*point.Polygon{
+		"haversine NaN outer radius":     haversine.CreateAnnulus(0, 0, 100, nan),
+		"haversine infinite radii":       haversine.CreateAnnulus(0, 0, -inf, 1000),
+		"vincenty infinite outer radius": vincenty.CreateAnnulus(0, 0, 100, inf),
+	}
+	for name, annulus := range annuli {
+		if annulus != nil {
+			t.Errorf("CreateAnnulus(%s) = %+v, want nil", name, annulus)
+		}
+	}
+}
+
+func TestIntersectPolygonWithShapeKeepsHoles(t *testing.T) {
+	polygon := &point.Polygon{
+		Exterior:  box(0, 0, 2, 2),
+		Interiors: [][]*point.Point{box(0.9, 0.9, 1.1, 1.1)},
+	}
+	ellipse := point.Ring(vincenty.CreateEllipse(1, 1, 60000, 40000, 30))
+
+	tests := []struct {
+		name      string
+		polygonal point.Polygonal
+	}{
+		{name: "polygon", polygonal: polygon},
+		{name: "multi polygon", polygonal: point.MultiPolygon{polygon, {Exterior: box(10, 10, 11, 11)}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			collection, err := IntersectPolygonWithShape(test.polygonal, ellipse, Options{})
+			if err != nil {
+				t.Fatalf("IntersectPolygonWithShape() error = %v", err)
+			}
+			if len(collection.Polygons) != 1 || len(collection.Polygons[0].Interiors) != 1 {
+				t.Errorf("IntersectPolygonWithShape() = %d polygons, want 1 with a hole", len(collection.Polygons))
+			}
+		})
+	}
+}
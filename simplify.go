@@ -0,0 +1,67 @@
+package gogeospace
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+// Simplify removes vertices of polygons that deviate less than the tolerance
+// in meters from the simplified edges - such as the hundreds of disc vertices
+// in an intersection result. Douglas-Peucker may collapse or cross parts, the
+// topology preserving variant keeps every part and hole valid. Tolerances are
+// measured in an azimuthal equidistant projection centered on the polygons
+// Params:
+// Polygonal rings, polygons or multi polygons of lat,lng in degrees
+// ToleranceMeters largest distance a removed vertex may lie from its edge
+// PreserveTopology keeps parts and holes from collapsing or crossing
+// Opts optional settings such as repair - the projection is always azimuthal
+// equidistant
+func Simplify(polygonal point.Polygonal, toleranceMeters float64, preserveTopology bool, opts ...Options) (multiPolygon point.MultiPolygon, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	if !(toleranceMeters >= 0) || math.IsInf(toleranceMeters, 0) {
+		return nil, fmt.Errorf("%w: simplify tolerance of %f meters", ErrInvalidDistance, toleranceMeters)
+	}
+
+	options := getOptions(opts)
+	polygons, err := polygonsOf(polygonal, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Densifying before simplifying would only be undone
+	options.Projection = ProjectionAzimuthalEquidistant
+	options.DensifyMeters = 0
+	simplify := (*geom.Geometry).Simplify
+	if preserveTopology {
+		simplify = (*geom.Geometry).SimplifyP
+	}
+
+	simplified, f, err := geographicGeometry("simplify", func(geo *geom.Geometry) (*geom.Geometry, error) {
+		return simplify(geo, toleranceMeters)
+	}, polygons, options)
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := collectionFromGeos(simplified, f, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return collection.Polygons, nil
+}
+
+// simplifyResult simplifies the polygons of a result topology preserving when
+// the options ask for it
+func simplifyResult(polygons point.MultiPolygon, opts Options) (point.MultiPolygon, error) {
+	if opts.SimplifyMeters <= 0 || len(polygons) == 0 {
+		return polygons, nil
+	}
+
+	return Simplify(polygons, opts.SimplifyMeters, true, opts)
+}
@@ -0,0 +1,78 @@
+package gogeospace
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+func TestSimplify(t *testing.T) {
+	// The bump sticks out about 111 meters south of the unit box
+	bumped := point.Ring{{Lat: 0, Lng: 0}, {Lat: -0.001, Lng: 0.5}, {Lat: 0, Lng: 1}, {Lat: 1, Lng: 1}, {Lat: 1, Lng: 0}}
+	// The hole is about 111 meters wide
+	holed := &point.Polygon{Exterior: box(0, 0, 1, 1), Interiors: [][]*point.Point{box(0.5, 0.5, 0.501, 0.501)}}
+	// Dropping the bump would leave the hole outside the box
+	bumpedAroundHole := &point.Polygon{Exterior: bumped, Interiors: [][]*point.Point{box(-0.0008, 0.495, -0.0002, 0.505)}}
+
+	tests := []struct {
+		name             string
+		polygonal        point.Polygonal
+		tolerance        float64
+		preserveTopology bool
+		vertices         int
+		holes            int
+	}{
+		{name: "bump above tolerance", polygonal: bumped, tolerance: 50, vertices: 5},
+		{name: "bump below tolerance", polygonal: bumped, tolerance: 200, vertices: 4},
+		{name: "zero tolerance", polygonal: bumped, vertices: 5},
+		{name: "hole above tolerance", polygonal: holed, tolerance: 50, vertices: 4, holes: 1},
+		{name: "hole below tolerance", polygonal: holed, tolerance: 500, vertices: 4, holes: 1},
+		{name: "bump below tolerance preserving topology", polygonal: bumped, tolerance: 200, preserveTopology: true, vertices: 4},
+		{name: "bump around hole preserving topology", polygonal: bumpedAroundHole, tolerance: 200, preserveTopology: true, vertices: 5, holes: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			simplified, err := Simplify(test.polygonal, test.tolerance, test.preserveTopology)
+			if err != nil {
+				t.Fatalf("Simplify() error = %v", err)
+			}
+			if len(simplified) != 1 {
+				t.Fatalf("Simplify() = %d polygons, want 1", len(simplified))
+			}
+			if vertices := len(openRing(simplified[0].Exterior)); vertices != test.vertices {
+				t.Errorf("Simplify() = %d vertices, want %d", vertices, test.vertices)
+			}
+			if holes := len(simplified[0].Interiors); holes != test.holes {
+				t.Errorf("Simplify() = %d holes, want %d", holes, test.holes)
+			}
+		})
+	}
+
+	// Dropping the bump leaves the corners of the box
+	simplified, err := Simplify(bumped, 200, false)
+	if err != nil {
+		t.Fatalf("Simplify() error = %v", err)
+	}
+	vertices := openRing(simplified[0].Exterior)
+	if len(vertices) != 4 {
+		t.Fatalf("Simplify() = %d vertices, want 4", len(vertices))
+	}
+	for _, corner := range box(0, 0, 1, 1) {
+		found := false
+		for _, p := range vertices {
+			found = found || (math.Abs(p.Lat-corner.Lat) < 1e-9 && math.Abs(p.Lng-corner.Lng) < 1e-9)
+		}
+		if !found {
+			t.Errorf("Simplify() = %v, want corner %+v", vertices, *corner)
+		}
+	}
+
+	for _, tolerance := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if _, err := Simplify(bumped, tolerance, false); !errors.Is(err, ErrInvalidDistance) {
+			t.Errorf("Simplify(%v) error = %v, want %v", tolerance, err, ErrInvalidDistance)
+		}
+	}
+}
@@ -17,3 +17,15 @@ func DegreesToRadians(d float64) float64 {
 func LengthToRadians(radius, earthRadius float64) float64 {
 	return (radius / earthRadius) // both in meters
 }
+
+// NormalizeLongitude wraps a longitude in degrees into [-180, 180]
+func NormalizeLongitude(lng float64) float64 {
+	return math.Remainder(lng, 360.0)
+}
+
+// UnwrapLongitude shifts a longitude in degrees by whole turns to within 180
+// degrees of the reference longitude - keeps shapes crossing the antimeridian
+// continuous
+func UnwrapLongitude(lng, reference float64) float64 {
+	return reference + math.Remainder(lng-reference, 360.0)
+}
@@ -0,0 +1,359 @@
+package gogeospace
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/jdejesus007/gogeospace/geom"
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/utils"
+)
+
+const (
+	// ValidGeometry reason of a polygon without issues
+	ValidGeometry = "Valid Geometry"
+	// InvalidCoordinate reason of a nil, NaN, infinite or out of range point
+	InvalidCoordinate = "Invalid Coordinate"
+	// TooFewPoints reason of a ring with fewer than three distinct points
+	TooFewPoints = "Too few points"
+	// SelfIntersection reason of ring edges crossing or overlapping each other
+	SelfIntersection = "Self-intersection"
+)
+
+// ValidationIssue is one problem found in a polygon with where it is
+type ValidationIssue struct {
+	// Reason one of the reason constants
+	Reason string `json:"reason"`
+	// Location of the issue - nil when it has no single location
+	Location *point.Point `json:"location,omitempty"`
+}
+
+// String formats the issue like GEOS isValidReason - Self-intersection[lat lng]
+func (i ValidationIssue) String() string {
+	if i.Location == nil {
+		return i.Reason
+	}
+	return fmt.Sprintf("%s[%v %v]", i.Reason, i.Location.Lat, i.Location.Lng)
+}
+
+// Validation is the result of validating a polygon
+type Validation struct {
+	Valid  bool              `json:"valid"`
+	Issues []ValidationIssue `json:"issues,omitempty"`
+}
+
+// Reason returns the first issue like GEOS isValidReason or Valid Geometry
+func (v *Validation) Reason() string {
+	if len(v.Issues) == 0 {
+		return ValidGeometry
+	}
+	return v.Issues[0].String()
+}
+
+// ValidatePolygon checks a ring of lat,lng points the way GEOS overlay
+// operations need it - valid coordinates, at least three distinct points and
+// no self-intersections. Repeated consecutive vertices are valid like in GEOS
+// and dropped before the checks. The ring may be open or closed
+func ValidatePolygon(coordinates []*point.Point) *Validation {
+	var issues []ValidationIssue
+
+	for _, p := range coordinates {
+		if p == nil {
+			issues = append(issues, ValidationIssue{Reason: InvalidCoordinate})
+			continue
+		}
+		if !validCoordinate(p) {
+			issues = append(issues, ValidationIssue{Reason: InvalidCoordinate, Location: p})
+		}
+	}
+
+	// Remaining checks need real coordinates
+	if len(issues) > 0 {
+		return &Validation{Issues: issues}
+	}
+
+	ring := removeRepeatedPoints(openRing(coordinates))
+	if countDistinctPoints(ring) < 3 {
+		var location *point.Point
+		if len(ring) > 0 {
+			location = ring[0]
+		}
+		issues = append(issues, ValidationIssue{Reason: TooFewPoints, Location: location})
+		return &Validation{Issues: issues}
+	}
+
+	// Rings crossing the antimeridian are checked unwrapped
+	for _, location := range selfIntersections(unwrapRing(ring)) {
+		location.Lng = utils.NormalizeLongitude(location.Lng)
+		issues = append(issues, ValidationIssue{Reason: SelfIntersection, Location: location})
+	}
+
+	return &Validation{Valid: len(issues) == 0, Issues: issues}
+}
+
+// RepairPolygon fixes a ring of lat,lng points so it can be used in polygon
+// operations - nil points and repeated consecutive vertices are dropped, the
+// ring is closed and self-intersections are resolved. A self-intersecting
+// ring such as a bow-tie comes back as one polygon per lobe
+func RepairPolygon(coordinates []*point.Point) (multiPolygon point.MultiPolygon, err error) {
+	// Catch internal C library panics
+	defer recoverGEOS(&err)
+
+	var ring []*point.Point
+	for _, p := range coordinates {
+		if p == nil {
+			continue
+		}
+		if !validCoordinate(p) {
+			return nil, &PolygonError{Coordinates: coordinates, Reason: ValidationIssue{Reason: InvalidCoordinate, Location: p}.String(), Err: ErrInvalidPolygon}
+		}
+		ring = append(ring, p)
+	}
+
+	ring = removeRepeatedPoints(openRing(ring))
+	if countDistinctPoints(ring) < 3 {
+		return nil, &PolygonError{Coordinates: coordinates, Reason: "fewer than three distinct points", Err: ErrTooFewPoints}
+	}
+
+	if len(selfIntersections(unwrapRing(ring))) == 0 {
+		return point.MultiPolygon{{Exterior: closeRing(ring)}}, nil
+	}
+
+	// A zero buffer keeps the lobes wound one way - buffer the ring in both
+	// directions and union them to keep every lobe
+	f, err := newFrameForPoints(Options{}, ring)
+	if err != nil {
+		return nil, err
+	}
+	reversed := make([]*point.Point, len(ring))
+	for i, p := range ring {
+		reversed[len(ring)-1-i] = p
+	}
+
+	var lobes *geom.Geometry
+	for _, r := range [][]*point.Point{ring, reversed} {
+		geo, err := geom.NewPolygon(pointsToCoords(r, f, f.lng))
+		if err != nil {
+			return nil, &PolygonError{Coordinates: coordinates, Reason: err.Error(), Err: ErrInvalidPolygon}
+		}
+
+		buffered, err := geo.Buffer(0)
+		if err != nil {
+			return nil, fmt.Errorf("failed repairing self-intersections: %w", err)
+		}
+
+		if lobes == nil {
+			lobes = buffered
+			continue
+		}
+
+		lobes, err = lobes.Union(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("failed repairing self-intersections: %w", err)
+		}
+	}
+
+	collection, err := collectionFromGeos(lobes, f, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(collection.Polygons) == 0 {
+		return nil, &PolygonError{Coordinates: coordinates, Reason: "repaired polygon has no area", Err: ErrInvalidPolygon}
+	}
+
+	return collection.Polygons, nil
+}
+
+// repairPolygonal runs RepairPolygon on every ring of rings, polygons and
+// multi polygons - repaired holes are cut out of their repaired exterior and
+// overlapping parts are merged
+func repairPolygonal(polygonal point.Polygonal) (point.MultiPolygon, error) {
+	if ring, ok := polygonal.(point.Ring); ok {
+		return RepairPolygon(ring)
+	}
+
+	polygons := polygonal.Polygons()
+	if len(polygons) == 0 {
+		return nil, &PolygonError{Reason: "no polygons", Err: ErrInvalidPolygon}
+	}
+
+	parts := make([]point.Polygonal, 0, len(polygons))
+	for _, polygon := range polygons {
+		if polygon == nil {
+			return nil, &PolygonError{Reason: "nil polygon", Err: ErrInvalidPolygon}
+		}
+
+		part, err := RepairPolygon(polygon.Exterior)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, interior := range polygon.Interiors {
+			hole, err := RepairPolygon(interior)
+			if err != nil {
+				return nil, err
+			}
+
+			if part, err = Difference(part, hole); err != nil {
+				return nil, err
+			}
+		}
+
+		parts = append(parts, part)
+	}
+
+	repaired := parts[0].Polygons()
+	if len(parts) > 1 {
+		var err error
+		if repaired, err = UnionAll(parts); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(repaired) == 0 {
+		return nil, &PolygonError{Reason: "repaired polygon has no area", Err: ErrInvalidPolygon}
+	}
+
+	return repaired, nil
+}
+
+func validCoordinate(p *point.Point) bool {
+	return !math.IsNaN(p.Lat) && !math.IsNaN(p.Lng) &&
+		!math.IsInf(p.Lat, 0) && !math.IsInf(p.Lng, 0) &&
+		p.Lat >= -90 && p.Lat <= 90
+}
+
+// openRing drops the closing point of a closed ring
+func openRing(ring []*point.Point) []*point.Point {
+	if len(ring) > 1 && *ring[0] == *ring[len(ring)-1] {
+		return ring[:len(ring)-1]
+	}
+	return ring
+}
+
+// closeRing repeats the first point of an open ring at the end
+func closeRing(ring []*point.Point) []*point.Point {
+	closed := make([]*point.Point, 0, len(ring)+1)
+	closed = append(closed, ring...)
+	if len(ring) > 0 {
+		closed = append(closed, &point.Point{Lat: ring[0].Lat, Lng: ring[0].Lng})
+	}
+	return closed
+}
+
+// removeRepeatedPoints drops vertices equal to the one before them
+func removeRepeatedPoints(ring []*point.Point) []*point.Point {
+	var cleaned []*point.Point
+	for _, p := range ring {
+		if len(cleaned) > 0 && *cleaned[len(cleaned)-1] == *p {
+			continue
+		}
+		cleaned = append(cleaned, p)
+	}
+	return openRing(cleaned)
+}
+
+// segment is one edge of an open ring from vertex i to the next vertex
+type segment struct {
+	i          int
+	a, b       *point.Point
+	minX, maxX float64
+}
+
+// selfIntersections returns where edges of an open ring without repeated
+// points cross or overlap - edges are swept in lat order so only edges with
+// overlapping lat ranges are compared
+func selfIntersections(ring []*point.Point) []*point.Point {
+	n := len(ring)
+	segments := make([]segment, n)
+	for i := range ring {
+		a, b := ring[i], ring[(i+1)%n]
+		segments[i] = segment{i: i, a: a, b: b, minX: math.Min(a.Lat, b.Lat), maxX: math.Max(a.Lat, b.Lat)}
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].minX < segments[j].minX })
+
+	var locations []*point.Point
+	seen := make(map[point.Point]struct{})
+	for i := range segments {
+		for j := i + 1; j < n && segments[j].minX <= segments[i].maxX; j++ {
+			s1, s2 := segments[i], segments[j]
+
+			// Neighbouring edges share a vertex - only an overlap is an issue
+			adjacent := (s1.i+1)%n == s2.i || (s2.i+1)%n == s1.i
+			location, ok := segmentIntersection(s1.a, s1.b, s2.a, s2.b, adjacent)
+			if !ok {
+				continue
+			}
+			if _, found := seen[*location]; !found {
+				seen[*location] = struct{}{}
+				locations = append(locations, location)
+			}
+		}
+	}
+	return locations
+}
+
+// segmentIntersection returns where segments ab and cd intersect - adjacent
+// segments only report collinear overlaps beyond their shared vertex
+func segmentIntersection(a, b, c, d *point.Point, adjacent bool) (*point.Point, bool) {
+	o1 := orientation(a, b, c)
+	o2 := orientation(a, b, d)
+	o3 := orientation(c, d, a)
+	o4 := orientation(c, d, b)
+
+	if o1 == 0 && o2 == 0 {
+		// Collinear - overlapping if any endpoint lies strictly inside the other
+		for _, p := range []*point.Point{c, d} {
+			if *p != *a && *p != *b && onSegment(a, b, p) {
+				return p, true
+			}
+		}
+		for _, p := range []*point.Point{a, b} {
+			if *p != *c && *p != *d && onSegment(c, d, p) {
+				return p, true
+			}
+		}
+		if !adjacent && (*a == *c || *a == *d || *b == *c || *b == *d) {
+			return a, true
+		}
+		return nil, false
+	}
+
+	if adjacent {
+		return nil, false
+	}
+
+	if o1*o2 <= 0 && o3*o4 <= 0 {
+		// Proper crossing or touching at a vertex
+		denominator := (a.Lat-b.Lat)*(c.Lng-d.Lng) - (a.Lng-b.Lng)*(c.Lat-d.Lat)
+		if denominator == 0 {
+			return a, true
+		}
+		t := ((a.Lat-c.Lat)*(c.Lng-d.Lng) - (a.Lng-c.Lng)*(c.Lat-d.Lat)) / denominator
+		return &point.Point{Lat: a.Lat + t*(b.Lat-a.Lat), Lng: a.Lng + t*(b.Lng-a.Lng)}, true
+	}
+
+	return nil, false
+}
+
+// orientation returns 1 for a counter clockwise turn a, b, c, -1 for clockwise
+// and 0 for collinear
+func orientation(a, b, c *point.Point) int {
+	cross := (b.Lat-a.Lat)*(c.Lng-a.Lng) - (b.Lng-a.Lng)*(c.Lat-a.Lat)
+	switch {
+	case cross > 0:
+		return 1
+	case cross < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// onSegment returns true if collinear point p lies within the bounds of ab
+func onSegment(a, b, p *point.Point) bool {
+	return p.Lat >= math.Min(a.Lat, b.Lat) && p.Lat <= math.Max(a.Lat, b.Lat) &&
+		p.Lng >= math.Min(a.Lng, b.Lng) && p.Lng <= math.Max(a.Lng, b.Lng)
+}
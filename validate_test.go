@@ -0,0 +1,73 @@
+package gogeospace
+
+import (
+	"math"
+	"testing"
+
+	"github.com/jdejesus007/gogeospace/point"
+)
+
+func TestValidatePolygon(t *testing.T) {
+	tests := []struct {
+		name   string
+		ring   point.Ring
+		reason string
+	}{
+		{name: "square", ring: box(0, 0, 1, 1), reason: ValidGeometry},
+		{name: "closed square", ring: append(box(0, 0, 1, 1), &point.Point{}), reason: ValidGeometry},
+		{name: "repeated point", ring: point.Ring{{}, {Lng: 1}, {Lng: 1}, {Lat: 1, Lng: 1}, {Lat: 1}}, reason: ValidGeometry},
+		{name: "bow-tie", ring: point.Ring{{}, {Lat: 2, Lng: 2}, {Lng: 2}, {Lat: 2}}, reason: SelfIntersection + "[1 1]"},
+		{name: "too few points", ring: point.Ring{{}, {Lng: 1}, {Lng: 1}}, reason: TooFewPoints + "[0 0]"},
+		{name: "NaN coordinate", ring: point.Ring{{}, {Lng: 1}, {Lat: math.NaN(), Lng: 1}}, reason: InvalidCoordinate + "[NaN 1]"},
+		{name: "nil point", ring: point.Ring{{}, nil, {Lng: 1}, {Lat: 1}}, reason: InvalidCoordinate},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validation := ValidatePolygon(test.ring)
+			if validation.Reason() != test.reason || validation.Valid != (test.reason == ValidGeometry) {
+				t.Errorf("ValidatePolygon() = %v, %q, want %q", validation.Valid, validation.Reason(), test.reason)
+			}
+		})
+	}
+}
+
+func TestRepairEveryRing(t *testing.T) {
+	bowTie := point.Ring{{}, {Lat: 2, Lng: 2}, {Lng: 2}, {Lat: 2}}
+	polygon := &point.Polygon{Exterior: bowTie, Interiors: [][]*point.Point{box(0.9, 1.5, 1.1, 1.6)}}
+
+	tests := []struct {
+		name      string
+		polygonal point.Polygonal
+		location  *point.Point
+		contains  bool
+	}{
+		{name: "ring lobe", polygonal: bowTie, location: &point.Point{Lat: 1, Lng: 0.3}, contains: true},
+		{name: "polygon lobe", polygonal: polygon, location: &point.Point{Lat: 1, Lng: 1.8}, contains: true},
+		{name: "polygon other lobe", polygonal: polygon, location: &point.Point{Lat: 1, Lng: 0.3}, contains: true},
+		{name: "polygon hole", polygonal: polygon, location: &point.Point{Lat: 1, Lng: 1.55}},
+		{name: "multi polygon overlap", polygonal: point.MultiPolygon{{Exterior: box(0, 0, 2, 2)}, {Exterior: box(1, 1, 3, 3)}},
+			location: &point.Point{Lat: 1.5, Lng: 1.5}, contains: true},
+		{name: "multi polygon lobe", polygonal: point.MultiPolygon{polygon, {Exterior: box(5, 5, 6, 6)}},
+			location: &point.Point{Lat: 1, Lng: 0.3}, contains: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			contains, err := Contains(test.polygonal, test.location, Options{Repair: true})
+			if err != nil || contains != test.contains {
+				t.Errorf("Contains() = %v, %v, want %v", contains, err, test.contains)
+			}
+
+			polygons, err := polygonsOf(test.polygonal, Options{Repair: true})
+			if err != nil {
+				t.Fatalf("polygonsOf() error = %v", err)
+			}
+			for _, polygon := range polygons {
+				if validation := ValidatePolygon(polygon.Exterior); !validation.Valid {
+					t.Errorf("polygonsOf() exterior = %s, want %s", validation.Reason(), ValidGeometry)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package vincenty
+
+import (
+	"math"
+
+	"github.com/jdejesus007/gogeospace/haversine"
+	"github.com/jdejesus007/gogeospace/point"
+	"github.com/jdejesus007/gogeospace/utils"
+)
+
+const (
+	// MAX_ITERATIONS bounds the inverse formula which fails to converge for
+	// nearly antipodal points and the direct formula on non-finite inputs
+	MAX_ITERATIONS = 200
+)
+
+var (
+	eSquared = f * (2 - f) // First eccentricity squared
+	qPole    = authalicQ(1.0)
+	// Radius of the sphere with the same surface area as the ellipsoid
+	authalicRadius = a * math.Sqrt(qPole/2.0)
+)
+
+// Distance returns the geodesic distance in meters between two lat,lng points
+// in degrees on the WGS-84 ellipsoid with the inverse vincenty formula - falls
+// back to the haversine distance for nearly antipodal points that do not
+// converge
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	distance, _ := Inverse(lat1, lng1, lat2, lng2)
+	return distance
+}
+
+// Inverse returns the geodesic distance in meters and the starting bearing in
+// degrees from the first to the second lat,lng point in degrees on the WGS-84
+// ellipsoid - falls back to the great circle for nearly antipodal points that
+// do not converge
+func Inverse(lat1, lng1, lat2, lng2 float64) (float64, float64) {
+	L := utils.DegreesToRadians(lng2 - lng1)
+	tanU1 := (1.0 - f) * math.Tan(utils.DegreesToRadians(lat1))
+	cosU1 := 1.0 / math.Sqrt(1.0+tanU1*tanU1)
+	sinU1 := tanU1 * cosU1
+	tanU2 := (1.0 - f) * math.Tan(utils.DegreesToRadians(lat2))
+	cosU2 := 1.0 / math.Sqrt(1.0+tanU2*tanU2)
+	sinU2 := tanU2 * cosU2
+
+	var (
+		lambda                    = L
+		sinLambda, cosLambda      float64
+		sinSigma, cosSigma, sigma float64
+		cos2Alpha, cosSigmaM2     float64
+		converged                 bool
+	)
+
+	for i := 0; i < MAX_ITERATIONS; i++ {
+		sinLambda = math.Sin(lambda)
+		cosLambda = math.Cos(lambda)
+		sinSigma = math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+		if sinSigma == 0 {
+			return 0, 0 // coincident points
+		}
+		cosSigma = sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma = math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha = 1 - sinAlpha*sinAlpha
+		cosSigmaM2 = 0 // equatorial line
+		if cos2Alpha != 0 {
+			cosSigmaM2 = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+		C := (f / 16) * cos2Alpha * (4 + f*(4-3*cos2Alpha))
+		prevLambda := lambda
+		lambda = L + (1-C)*f*sinAlpha*(sigma+C*sinSigma*(cosSigmaM2+C*cosSigma*(-1+2*cosSigmaM2*cosSigmaM2)))
+
+		// break after converging to tolerance
+		if math.Abs(lambda-prevLambda) < 0.000000000001 {
+			converged = true
+			break
+		}
+	}
+
+	if !converged {
+		return haversine.Distance(lat1, lng1, lat2, lng2), haversine.Bearing(lat1, lng1, lat2, lng2)
+	}
+
+	uSquared := cos2Alpha * (aSquared - bSquared) / bSquared
+	A := 1 + (uSquared/16384)*(4096+uSquared*(-768+uSquared*(320-175*uSquared)))
+	B := (uSquared / 1024) * (256 + uSquared*(-128+uSquared*(74-47*uSquared)))
+	deltaSigma := B * sinSigma * (cosSigmaM2 + (B/4.0)*(cosSigma*(-1+2*cosSigmaM2*cosSigmaM2)-(B/6.0)*cosSigmaM2*(-3+4*sinSigma*sinSigma)*(-3+4*cosSigmaM2*cosSigmaM2)))
+
+	bearing := math.Atan2(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+
+	return b * A * (sigma - deltaSigma), utils.RadToDegrees(bearing)
+}
+
+// RingLength returns the length in meters of a ring of lat,lng points in
+// degrees on the WGS-84 ellipsoid including the closing edge
+func RingLength(ring []*point.Point) float64 {
+	var length float64
+	for i := range ring {
+		p1, p2 := ring[i], ring[(i+1)%len(ring)]
+		length += Distance(p1.Lat, p1.Lng, p2.Lat, p2.Lng)
+	}
+	return length
+}
+
+// RingArea returns the area in square meters enclosed by a ring of lat,lng
+// points in degrees on the WGS-84 ellipsoid - open or closed, either winding.
+// Computed on the authalic sphere which has the area of the ellipsoid
+func RingArea(ring []*point.Point) float64 {
+	authalic := make([]*point.Point, len(ring))
+	for i, p := range ring {
+		authalic[i] = &point.Point{Lat: AuthalicLatitude(p.Lat), Lng: p.Lng}
+	}
+
+	return math.Abs(haversine.SphericalExcess(authalic)) * authalicRadius * authalicRadius
+}
+
+// AuthalicLatitude converts a geodetic latitude in degrees to the latitude in
+// degrees on the sphere with equal area
+func AuthalicLatitude(lat float64) float64 {
+	q := authalicQ(math.Sin(utils.DegreesToRadians(lat)))
+	return utils.RadToDegrees(math.Asin(math.Max(-1.0, math.Min(1.0, q/qPole))))
+}
+
+func authalicQ(sinLat float64) float64 {
+	e := math.Sqrt(eSquared)
+	eSinLat := e * sinLat
+	return (1 - eSquared) * (sinLat/(1-eSinLat*eSinLat) - (1/(2*e))*math.Log((1-eSinLat)/(1+eSinLat)))
+}
+
+// GeodeticLatitude converts a latitude in degrees on the sphere with equal
+// area back to the geodetic latitude in degrees - series accurate to about a
+// millimeter
+func GeodeticLatitude(authalicLat float64) float64 {
+	beta := utils.DegreesToRadians(authalicLat)
+	e4 := eSquared * eSquared
+	e6 := e4 * eSquared
+
+	phi := beta +
+		(eSquared/3.0+31.0*e4/180.0+517.0*e6/5040.0)*math.Sin(2.0*beta) +
+		(23.0*e4/360.0+251.0*e6/3780.0)*math.Sin(4.0*beta) +
+		(761.0*e6/45360.0)*math.Sin(6.0*beta)
+
+	return utils.RadToDegrees(phi)
+}
@@ -3,7 +3,7 @@ package vincenty
 import (
 	"math"
 
-	"github.com/jdejesus007/gogeospace/constants"
+	"github.com/jdejesus007/gogeospace/disc"
 	"github.com/jdejesus007/gogeospace/point"
 	"github.com/jdejesus007/gogeospace/utils"
 )
@@ -18,21 +18,58 @@ const (
 )
 
 // CreateVincentyDisc creates a disc with center lat1, lng1, and radius in meters
-func CreateDisc(lat1, lng1, radius float64) []*point.Point {
+// optional disc options set the vertex count or max error in meters
+func CreateDisc(lat1, lng1, radius float64, opts ...disc.Options) []*point.Point {
+	coordinates, _ := CreateDiscWithErrorBound(lat1, lng1, radius, disc.Resolve(opts...))
+	return coordinates
+}
+
+// CreateDiscWithErrorBound creates a disc like CreateDisc and also returns the
+// achieved max chord-to-arc error in meters - bounded with the semi-major
+// axis. Nil for a disc reaching past both poles or without a disc.Valid center
+// and radius
+func CreateDiscWithErrorBound(lat1, lng1, radius float64, opts disc.Options) ([]*point.Point, float64) {
+	if !disc.Valid(lat1, lng1, radius) {
+		return nil, 0
+	}
+
 	// all going in as degrees and meters
-	steps := constants.NUM_STEPS_PRECISION // precision
-	var coordinates []*point.Point
-	for i := 0.0; i < steps; i++ {
-		startBearing := float64(i * -360.0 / steps)
+	steps, maxError := disc.Steps(radius, a, opts) // precision
+	coordinates := make([]*point.Point, 0, steps)
+	for i := 0; i < steps; i++ {
+		startBearing := float64(i) * -360.0 / float64(steps)
 		lat2, lng2, _ := CalculateVincentyCoordinate(lat1, lng1, radius, startBearing)
 		coordinates = append(coordinates, &point.Point{Lat: lat2, Lng: lng2})
 	}
-	return coordinates
+
+	// A disc reaching past one pole winds around it - close it along the pole
+	north := radius > Distance(lat1, lng1, 90.0, lng1)
+	south := radius > Distance(lat1, lng1, -90.0, lng1)
+	if north && south {
+		// Winding around neither pole the ring cannot tell its inside apart
+		return nil, maxError
+	}
+	if north != south {
+		coordinates = disc.EnclosePole(coordinates, math.Copysign(90.0, lat1), 0)
+	}
+
+	return coordinates, maxError
+}
+
+// Generator creates vincenty discs - accurate on the WGS-84 ellipsoid
+type Generator struct {
+	// Options set the vertex count or max error in meters of each disc
+	Options disc.Options
+}
+
+// CreateDisc creates a disc with center lat, lng in degrees and radius in meters
+func (g Generator) CreateDisc(lat, lng, radius float64) []*point.Point {
+	return CreateDisc(lat, lng, radius, g.Options)
 }
 
 // CalculateVincentyCoordinate gets a point on the disc given center in
 // degrees, radius distance in meters, and bearing in degrees
-// Returns latitude2, longitude2, and ending bearing in degrees
+// Returns latitude2, longitude2 wrapped into [-180, 180], and ending bearing in degrees
 func CalculateVincentyCoordinate(lat1, lng1, radius, startBearing float64) (float64, float64, float64) {
 	phi1 := utils.DegreesToRadians(lat1)
 	alpha1 := utils.DegreesToRadians(startBearing)
@@ -59,7 +96,8 @@ func CalculateVincentyCoordinate(lat1, lng1, radius, startBearing float64) (floa
 	// eq. 4
 	B := (uSquared / 1024) * (256 + uSquared*(-128+uSquared*(74-47*uSquared)))
 
-	// iterate until there is a negligible change in sigma
+	// iterate until there is a negligible change in sigma - bounded for
+	// inputs such as NaN that never converge
 	var (
 		deltaSigma  float64
 		sOverbA     = s / (b * A)
@@ -71,7 +109,7 @@ func CalculateVincentyCoordinate(lat1, lng1, radius, startBearing float64) (floa
 		cos2SigmaM2 float64
 	)
 
-	for {
+	for i := 0; i < MAX_ITERATIONS; i++ {
 		// eq. 5
 		sigmaM2 = 2.0*sigma1 + sigma
 		cosSigmaM2 = math.Cos(sigmaM2)
@@ -120,7 +158,7 @@ func CalculateVincentyCoordinate(lat1, lng1, radius, startBearing float64) (floa
 
 	// coordinate result
 	latitude := utils.RadToDegrees(phi2)
-	longitude := lng1 + utils.RadToDegrees(L)
+	longitude := utils.NormalizeLongitude(lng1 + utils.RadToDegrees(L))
 
 	return latitude, longitude, endBearing
 }